@@ -98,14 +98,17 @@ class LogRow:
                         ("ram(mb)", "ram"),
                         ("time(ms)", "time"),
                         ("p(bitlength)", "p"),
-                        ("time(ns)", "time")
+                        ("time(ns)", "time"),
+                        ("nlognCost", "nlogn_cost")
                     ]
                     for i, t in mappings:
                         s = s.replace(i, t)
                     return s
                 # Check headers
                 headers = list(map(mapping, headers))
-                if headers != cls.get_headers():
+                # Trailing columns with a default value are optional
+                expected = cls.get_headers()
+                if headers != expected[:len(headers)] or len(headers) < len(cls.get_required_headers()):
                     raise Exception("Wrong headers:\nExpected: {}\nFound: {}".format(
                         cls.get_headers(), headers
                     ))
@@ -117,6 +120,12 @@ class LogRow:
         header = inspect.getfullargspec(cls.__init__).args
         return list(filter(lambda x: x != 'self', header))
 
+    @classmethod
+    def get_required_headers(cls):
+        spec = inspect.getfullargspec(cls.__init__)
+        nb_optional = len(spec.defaults) if spec.defaults else 0
+        return cls.get_headers()[:len(cls.get_headers()) - nb_optional]
+
     def get_row(self):
         return [getattr(self, h) for h in self.get_headers() if h != "category"]
 
@@ -236,7 +245,8 @@ class ECLogRow(LogRow):
     # We need category to easily verify that we pass the correct number of args.
     def __init__(
         self, framework, category, curve, operation, input_path, ram, time,
-        nb_physical_cores, nb_logical_cores, count, cpu
+        nb_physical_cores, nb_logical_cores, count, cpu,
+        throughput=0, nlogn_cost=0
     ):
         super().__init__(framework)
         # TODO sanity checks
@@ -250,6 +260,8 @@ class ECLogRow(LogRow):
         self.nb_logical_cores = int(nb_logical_cores)
         self.count = int(count)
         self.cpu = cpu
+        self.throughput = float(throughput)
+        self.nlogn_cost = float(nlogn_cost)
 
     def get_static_rows(self):
         return (f"{self.framework},{self.curve},{self.operation},"
@@ -0,0 +1,376 @@
+// Package circom reads and writes constraint systems and witnesses in the binary R1CS and wtns
+// formats of iden3, such that gnark proves the very constraints snarkjs does and conversely
+package circom
+
+import (
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"math/big"
+
+	"github.com/consensys/gnark-crypto/ecc"
+	"github.com/consensys/gnark/backend/witness"
+	"github.com/consensys/gnark/constraint"
+	cs_bls12381 "github.com/consensys/gnark/constraint/bls12-381"
+	cs_bn254 "github.com/consensys/gnark/constraint/bn254"
+)
+
+// Curves are the curves whose scalar field the R1CS files are read over
+var Curves = []ecc.ID{ecc.BN254, ecc.BLS12_381}
+
+// section types of the R1CS file
+const (
+	sectionHeader      = 1
+	sectionConstraints = 2
+	sectionCustomGates = 4
+)
+
+// ReadR1CS reads a constraint system in the binary R1CS format of iden3.
+//
+// The wires keep their index: wire 0 is the constant one, the public outputs and inputs are the public
+// variables and every other wire, the private inputs as the intermediate signals, is a secret variable,
+// as the wtns file of circom assigns all of them. The constraints are thus only checked by the solver
+// and their number is the one of the file.
+func ReadR1CS(r io.Reader) (constraint.ConstraintSystem, error) {
+	sections, err := readSections(r, "r1cs", 1)
+	if err != nil {
+		return nil, err
+	}
+	if _, ok := sections[sectionCustomGates]; ok {
+		return nil, errors.New("r1cs: custom gates are not supported")
+	}
+
+	header, ok := sections[sectionHeader]
+	if !ok {
+		return nil, errors.New("r1cs: missing header section")
+	}
+	d := decoder{b: header}
+	n8 := int(d.uint32())
+	prime := d.element(n8)
+	nbWires := int(d.uint32())
+	nbPubOut := int(d.uint32())
+	nbPubIn := int(d.uint32())
+	d.uint32() // private inputs, secret variables as the other wires
+	d.uint64() // labels, in the sym file
+	nbConstraints := int(d.uint32())
+	if d.err != nil {
+		return nil, fmt.Errorf("r1cs: header: %w", d.err)
+	}
+	nbPublic := 1 + nbPubOut + nbPubIn
+	if nbWires < nbPublic {
+		return nil, fmt.Errorf("r1cs: %d wires for %d public variables", nbWires, nbPublic)
+	}
+
+	curveID, err := CurveOf(prime)
+	if err != nil {
+		return nil, err
+	}
+	var ccs constraint.R1CS
+	switch curveID {
+	case ecc.BN254:
+		ccs = cs_bn254.NewR1CS(nbConstraints)
+	case ecc.BLS12_381:
+		ccs = cs_bls12381.NewR1CS(nbConstraints)
+	}
+
+	ccs.AddPublicVariable("1")
+	for i := 1; i < nbWires; i++ {
+		if i < nbPublic {
+			ccs.AddPublicVariable(fmt.Sprintf("w%d", i))
+		} else {
+			ccs.AddSecretVariable(fmt.Sprintf("w%d", i))
+		}
+	}
+
+	constraints, ok := sections[sectionConstraints]
+	if !ok {
+		return nil, errors.New("r1cs: missing constraints section")
+	}
+	d = decoder{b: constraints}
+	linearExpression := func() constraint.LinearExpression {
+		n := int(d.uint32())
+		if d.err == nil && n > len(d.b)/(4+n8) {
+			d.err = io.ErrUnexpectedEOF
+		}
+		if d.err != nil {
+			return nil
+		}
+		l := make(constraint.LinearExpression, n)
+		for i := range l {
+			wire := int(d.uint32())
+			coeff := d.element(n8)
+			if d.err == nil && wire >= nbWires {
+				d.err = fmt.Errorf("wire %d out of %d wires", wire, nbWires)
+			}
+			if d.err != nil {
+				return nil
+			}
+			l[i] = ccs.MakeTerm(ccs.FromInterface(coeff), wire)
+		}
+		return l
+	}
+
+	bID := ccs.AddBlueprint(&constraint.BlueprintGenericR1C{})
+	for i := 0; i < nbConstraints; i++ {
+		var c constraint.R1C
+		c.L = linearExpression()
+		c.R = linearExpression()
+		c.O = linearExpression()
+		if d.err != nil {
+			return nil, fmt.Errorf("r1cs: constraint %d: %w", i, d.err)
+		}
+		ccs.AddR1C(c, bID)
+	}
+
+	return ccs, nil
+}
+
+// ReadWitness reads the assignment of every wire of ccs, read by ReadR1CS, in the wtns format of iden3
+// and returns the full witness of ccs
+func ReadWitness(r io.Reader, ccs constraint.ConstraintSystem) (witness.Witness, error) {
+	sections, err := readSections(r, "wtns", 2)
+	if err != nil {
+		return nil, err
+	}
+
+	header, ok := sections[1]
+	if !ok {
+		return nil, errors.New("wtns: missing header section")
+	}
+	d := decoder{b: header}
+	n8 := int(d.uint32())
+	prime := d.element(n8)
+	nbValues := int(d.uint32())
+	if d.err != nil {
+		return nil, fmt.Errorf("wtns: header: %w", d.err)
+	}
+	if prime.Cmp(ccs.Field()) != 0 {
+		return nil, errors.New("wtns: the field differs from the one of the constraint system")
+	}
+	_, nbSecret, nbPublic := ccs.GetNbVariables()
+	if nbValues != nbPublic+nbSecret {
+		return nil, fmt.Errorf("wtns: %d values for %d wires", nbValues, nbPublic+nbSecret)
+	}
+
+	values, ok := sections[2]
+	if !ok {
+		return nil, errors.New("wtns: missing values section")
+	}
+	if len(values) != nbValues*n8 {
+		return nil, fmt.Errorf("wtns: %d bytes of values for %d values", len(values), nbValues)
+	}
+	d = decoder{b: values}
+	if d.element(n8).Cmp(big.NewInt(1)) != 0 {
+		return nil, errors.New("wtns: the first wire is not the constant one")
+	}
+
+	// the constant one is not part of the witness
+	w, err := witness.New(ccs.Field())
+	if err != nil {
+		return nil, err
+	}
+	ch := make(chan any)
+	go func() {
+		defer close(ch)
+		for i := 1; i < nbValues; i++ {
+			ch <- d.element(n8)
+		}
+	}()
+	if err := w.Fill(nbPublic-1, nbSecret, ch); err != nil {
+		for range ch {
+		}
+		return nil, err
+	}
+	return w, nil
+}
+
+// WriteR1CS writes an R1CS compiled by gnark in the binary R1CS format of iden3.
+//
+// The wires of gnark have the layout of circom: the constant one, the public inputs, which are
+// the public inputs of circom as gnark has no outputs, the secret inputs then the internal wires.
+func WriteR1CS(w io.Writer, ccs constraint.R1CS) error {
+	internal, secret, public := ccs.GetNbVariables()
+	nbWires := internal + secret + public
+	n8 := elementSize(ccs.Field())
+	constraints := ccs.GetR1Cs()
+
+	var header encoder
+	header.uint32(uint32(n8))
+	header.element(ccs.Field(), n8)
+	header.uint32(uint32(nbWires))
+	header.uint32(0)
+	header.uint32(uint32(public - 1))
+	header.uint32(uint32(secret))
+	header.uint64(uint64(nbWires))
+	header.uint32(uint32(len(constraints)))
+
+	var body encoder
+	linearExpression := func(l constraint.LinearExpression) {
+		body.uint32(uint32(len(l)))
+		for _, t := range l {
+			body.uint32(t.VID)
+			body.element(ccs.ToBigInt(ccs.GetCoefficient(int(t.CID))), n8)
+		}
+	}
+	for _, c := range constraints {
+		linearExpression(c.L)
+		linearExpression(c.R)
+		linearExpression(c.O)
+	}
+
+	// the wires are their own labels
+	var labels encoder
+	for i := 0; i < nbWires; i++ {
+		labels.uint64(uint64(i))
+	}
+
+	return writeSections(w, "r1cs", 1, header, body, labels)
+}
+
+// WriteWitness writes the value of every wire, the first being the constant one, in the wtns format of iden3
+func WriteWitness(w io.Writer, field *big.Int, wires []*big.Int) error {
+	n8 := elementSize(field)
+
+	var header encoder
+	header.uint32(uint32(n8))
+	header.element(field, n8)
+	header.uint32(uint32(len(wires)))
+
+	var values encoder
+	for _, v := range wires {
+		values.element(v, n8)
+	}
+
+	return writeSections(w, "wtns", 2, header, values)
+}
+
+// CurveOf returns the curve of Curves with the scalar field of the prime
+func CurveOf(prime *big.Int) (ecc.ID, error) {
+	for _, id := range Curves {
+		if id.ScalarField().Cmp(prime) == 0 {
+			return id, nil
+		}
+	}
+	return ecc.UNKNOWN, fmt.Errorf("r1cs: field %s is not the scalar field of bn254 or bls12_381", prime)
+}
+
+// readSections reads the sections of a binary file of iden3, by their type
+func readSections(r io.Reader, magic string, version uint32) (map[uint32][]byte, error) {
+	b, err := io.ReadAll(r)
+	if err != nil {
+		return nil, err
+	}
+	if len(b) < 12 || string(b[:4]) != magic {
+		return nil, fmt.Errorf("not a %s file", magic)
+	}
+	if v := binary.LittleEndian.Uint32(b[4:8]); v != version {
+		return nil, fmt.Errorf("%s: unsupported version %d", magic, v)
+	}
+
+	d := decoder{b: b[8:]}
+	nbSections := int(d.uint32())
+	sections := make(map[uint32][]byte, nbSections)
+	for i := 0; i < nbSections; i++ {
+		t := d.uint32()
+		size := d.uint64()
+		if d.err == nil && size > uint64(len(d.b)) {
+			d.err = io.ErrUnexpectedEOF
+		}
+		if d.err != nil {
+			return nil, fmt.Errorf("%s: section %d: %w", magic, i, d.err)
+		}
+		if _, ok := sections[t]; ok {
+			return nil, fmt.Errorf("%s: duplicated section of type %d", magic, t)
+		}
+		sections[t] = d.b[:size]
+		d.b = d.b[size:]
+	}
+	return sections, nil
+}
+
+// writeSections writes the sections of a binary file of iden3, their types numbered from 1
+func writeSections(w io.Writer, magic string, version uint32, sections ...[]byte) error {
+	b := encoder(magic)
+	b.uint32(version)
+	b.uint32(uint32(len(sections)))
+	for i, section := range sections {
+		b.uint32(uint32(i + 1))
+		b.uint64(uint64(len(section)))
+		b = append(b, section...)
+	}
+	_, err := w.Write(b)
+	return err
+}
+
+// elementSize returns the size of the field elements of the iden3 formats, in words of 8 bytes
+func elementSize(field *big.Int) int {
+	return (field.BitLen() + 63) / 64 * 8
+}
+
+// encoder encodes the little endian integers of the iden3 formats
+type encoder []byte
+
+func (e *encoder) uint32(v uint32) {
+	*e = binary.LittleEndian.AppendUint32(*e, v)
+}
+
+func (e *encoder) uint64(v uint64) {
+	*e = binary.LittleEndian.AppendUint64(*e, v)
+}
+
+// element encodes a field element, or the prime, on n8 bytes in little endian and not in Montgomery form
+func (e *encoder) element(v *big.Int, n8 int) {
+	b := v.FillBytes(make([]byte, n8))
+	for i := range b {
+		*e = append(*e, b[len(b)-1-i])
+	}
+}
+
+// decoder decodes the little endian integers of the iden3 formats, keeping the first error
+type decoder struct {
+	b   []byte
+	err error
+}
+
+func (d *decoder) next(n int) []byte {
+	if d.err != nil {
+		return nil
+	}
+	if len(d.b) < n {
+		d.err = io.ErrUnexpectedEOF
+		return nil
+	}
+	b := d.b[:n]
+	d.b = d.b[n:]
+	return b
+}
+
+func (d *decoder) uint32() uint32 {
+	b := d.next(4)
+	if b == nil {
+		return 0
+	}
+	return binary.LittleEndian.Uint32(b)
+}
+
+func (d *decoder) uint64() uint64 {
+	b := d.next(8)
+	if b == nil {
+		return 0
+	}
+	return binary.LittleEndian.Uint64(b)
+}
+
+// element decodes a field element of n8 bytes, in little endian and not in Montgomery form
+func (d *decoder) element(n8 int) *big.Int {
+	b := d.next(n8)
+	if b == nil {
+		return new(big.Int)
+	}
+	be := make([]byte, n8)
+	for i := range b {
+		be[n8-1-i] = b[i]
+	}
+	return new(big.Int).SetBytes(be)
+}
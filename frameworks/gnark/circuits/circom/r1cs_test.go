@@ -0,0 +1,177 @@
+package circom
+
+import (
+	"bytes"
+	"encoding/binary"
+	"math/big"
+	"testing"
+
+	"github.com/consensys/gnark-crypto/ecc"
+	"github.com/consensys/gnark/backend/groth16"
+	"github.com/consensys/gnark/constraint"
+	"github.com/consensys/gnark/frontend"
+	"github.com/consensys/gnark/frontend/cs/r1cs"
+	"github.com/consensys/gnark/test"
+	"github.com/zkCollective/zk-Harness/frameworks/gnark/circuits/toy/cubic"
+	"github.com/zkCollective/zk-Harness/frameworks/gnark/util"
+)
+
+// term is a coefficient of a wire of a linear combination of circom
+type term struct {
+	wire  uint32
+	coeff int64
+}
+
+// circomCubic are the constraints circom compiles circuits/benchmarks/cubic/circuit.circom to, over the
+// wires one, Y, X, xs[1] and xs[2]: X * X = xs[1], xs[1] * X = xs[2] and 0 = Y - xs[2] - X - 5
+var circomCubic = [][3][]term{
+	{{{2, 1}}, {{2, 1}}, {{3, 1}}},
+	{{{3, 1}}, {{2, 1}}, {{4, 1}}},
+	{nil, nil, {{1, 1}, {4, -1}, {2, -1}, {0, -5}}},
+}
+
+func TestReadR1CS(t *testing.T) {
+	assert := test.NewAssert(t)
+
+	for _, curveID := range Curves {
+		ccs, err := ReadR1CS(bytes.NewReader(writeR1CS(curveID.ScalarField(), 5, 0, 1, 1, circomCubic)))
+		assert.NoError(err)
+		assert.Equal(3, ccs.GetNbConstraints())
+		internal, secret, public := ccs.GetNbVariables()
+		assert.Equal([3]int{0, 3, 2}, [3]int{internal, secret, public})
+
+		pk, vk, err := groth16.Setup(ccs)
+		assert.NoError(err)
+
+		w, err := ReadWitness(bytes.NewReader(writeWitness(curveID.ScalarField(), 1, 35, 3, 9, 27)), ccs)
+		assert.NoError(err)
+		proof, err := groth16.Prove(ccs, pk, w)
+		assert.NoError(err)
+		publicWitness, err := w.Public()
+		assert.NoError(err)
+		assert.NoError(groth16.Verify(proof, vk, publicWitness))
+
+		// the intermediate signals are checked as the inputs
+		w, err = ReadWitness(bytes.NewReader(writeWitness(curveID.ScalarField(), 1, 35, 3, 9, 28)), ccs)
+		assert.NoError(err)
+		_, err = ccs.Solve(w)
+		assert.Error(err)
+	}
+}
+
+func TestReadR1CSErrors(t *testing.T) {
+	assert := test.NewAssert(t)
+
+	_, err := ReadR1CS(bytes.NewReader(writeR1CS(ecc.BLS12_377.ScalarField(), 5, 0, 1, 1, circomCubic)))
+	assert.Error(err, "unsupported field")
+
+	b := writeR1CS(ecc.BN254.ScalarField(), 5, 0, 1, 1, circomCubic)
+	_, err = ReadR1CS(bytes.NewReader(b[:len(b)-1]))
+	assert.Error(err, "truncated file")
+
+	_, err = ReadR1CS(bytes.NewReader(writeR1CS(ecc.BN254.ScalarField(), 4, 0, 1, 1, circomCubic)))
+	assert.Error(err, "wire out of range")
+
+	ccs, err := ReadR1CS(bytes.NewReader(writeR1CS(ecc.BN254.ScalarField(), 5, 0, 1, 1, circomCubic)))
+	assert.NoError(err)
+	_, err = ReadWitness(bytes.NewReader(writeWitness(ecc.BN254.ScalarField(), 1, 35, 3, 9)), ccs)
+	assert.Error(err, "missing wire")
+	_, err = ReadWitness(bytes.NewReader(writeWitness(ecc.BLS12_381.ScalarField(), 1, 35, 3, 9, 27)), ccs)
+	assert.Error(err, "other field")
+}
+
+func TestWriteR1CS(t *testing.T) {
+	assert := test.NewAssert(t)
+
+	for _, curveID := range Curves {
+		ccs, err := frontend.Compile(curveID.ScalarField(), r1cs.NewBuilder, &cubic.CubicCircuit{})
+		assert.NoError(err)
+		w, err := frontend.NewWitness(&cubic.CubicCircuit{X: 3, Y: 35}, curveID.ScalarField())
+		assert.NoError(err)
+		wires, err := util.SolvedWires(ccs, w)
+		assert.NoError(err)
+
+		var bR1CS, bWitness bytes.Buffer
+		assert.NoError(WriteR1CS(&bR1CS, ccs.(constraint.R1CS)))
+		assert.NoError(WriteWitness(&bWitness, curveID.ScalarField(), wires))
+
+		// the exported circuit is proven as an imported circom circuit
+		imported, err := ReadR1CS(&bR1CS)
+		assert.NoError(err)
+		assert.Equal(ccs.GetNbConstraints(), imported.GetNbConstraints())
+		assert.Equal(ccs.GetNbPublicVariables(), imported.GetNbPublicVariables())
+		importedWitness, err := ReadWitness(&bWitness, imported)
+		assert.NoError(err)
+
+		pk, vk, err := groth16.Setup(imported)
+		assert.NoError(err)
+		proof, err := groth16.Prove(imported, pk, importedWitness)
+		assert.NoError(err)
+		publicWitness, err := w.Public()
+		assert.NoError(err)
+		assert.NoError(groth16.Verify(proof, vk, publicWitness))
+	}
+}
+
+// writeR1CS encodes the constraints in the R1CS format, the sections in the order of circom
+func writeR1CS(prime *big.Int, nbWires, nbPubOut, nbPubIn, nbPrvIn uint32, constraints [][3][]term) []byte {
+	n8 := len(prime.Bytes())
+	var header, body, labels bytes.Buffer
+	binary.Write(&header, binary.LittleEndian, uint32(n8))
+	header.Write(element(prime, prime, n8))
+	binary.Write(&header, binary.LittleEndian, []uint32{nbWires, nbPubOut, nbPubIn, nbPrvIn})
+	binary.Write(&header, binary.LittleEndian, uint64(nbWires))
+	binary.Write(&header, binary.LittleEndian, uint32(len(constraints)))
+	for _, c := range constraints {
+		for _, l := range c {
+			binary.Write(&body, binary.LittleEndian, uint32(len(l)))
+			for _, t := range l {
+				binary.Write(&body, binary.LittleEndian, t.wire)
+				body.Write(element(prime, big.NewInt(t.coeff), n8))
+			}
+		}
+	}
+	for i := uint64(0); i < uint64(nbWires); i++ {
+		binary.Write(&labels, binary.LittleEndian, i)
+	}
+	return file("r1cs", 1, header.Bytes(), body.Bytes(), labels.Bytes())
+}
+
+// writeWitness encodes the values of the wires in the wtns format
+func writeWitness(prime *big.Int, values ...int64) []byte {
+	n8 := len(prime.Bytes())
+	var header, body bytes.Buffer
+	binary.Write(&header, binary.LittleEndian, uint32(n8))
+	header.Write(element(prime, prime, n8))
+	binary.Write(&header, binary.LittleEndian, uint32(len(values)))
+	for _, v := range values {
+		body.Write(element(prime, big.NewInt(v), n8))
+	}
+	return file("wtns", 2, header.Bytes(), body.Bytes())
+}
+
+// file encodes the sections, numbered from 1, of a binary file of iden3
+func file(magic string, version uint32, sections ...[]byte) []byte {
+	var b bytes.Buffer
+	b.WriteString(magic)
+	binary.Write(&b, binary.LittleEndian, version)
+	binary.Write(&b, binary.LittleEndian, uint32(len(sections)))
+	for i, s := range sections {
+		binary.Write(&b, binary.LittleEndian, uint32(i+1))
+		binary.Write(&b, binary.LittleEndian, uint64(len(s)))
+		b.Write(s)
+	}
+	return b.Bytes()
+}
+
+// element encodes v, reduced modulo the prime unless it is the prime, on n8 bytes in little endian
+func element(prime *big.Int, v *big.Int, n8 int) []byte {
+	if v.Cmp(prime) != 0 {
+		v = new(big.Int).Mod(v, prime)
+	}
+	b := v.FillBytes(make([]byte, n8))
+	for i, j := 0, len(b)-1; i < j; i, j = i+1, j-1 {
+		b[i], b[j] = b[j], b[i]
+	}
+	return b
+}
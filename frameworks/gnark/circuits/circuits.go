@@ -7,19 +7,19 @@ import (
 
 	"github.com/consensys/gnark-crypto/ecc"
 
-	"github.com/consensys/gnark/backend/groth16"
 	"github.com/consensys/gnark/backend/witness"
+	"github.com/consensys/gnark/constraint"
 	"github.com/consensys/gnark/frontend"
 	"github.com/consensys/gnark/std/math/emulated"
 	"github.com/consensys/gnark/std/math/uints"
-	"github.com/zkCollective/zk-Harness/frameworks/gnark/circuits/groth16bls12377verifier"
-	"github.com/zkCollective/zk-Harness/frameworks/gnark/circuits/groth16bls24315verifier"
 	"github.com/zkCollective/zk-Harness/frameworks/gnark/circuits/prf/mimc"
 	"github.com/zkCollective/zk-Harness/frameworks/gnark/circuits/prf/sha2"
 	"github.com/zkCollective/zk-Harness/frameworks/gnark/circuits/toy/cubic"
 	emulate "github.com/zkCollective/zk-Harness/frameworks/gnark/circuits/toy/emulate"
 	"github.com/zkCollective/zk-Harness/frameworks/gnark/circuits/toy/exponentiate"
 	"github.com/zkCollective/zk-Harness/frameworks/gnark/circuits/toy/exponentiate_opt"
+	"github.com/zkCollective/zk-Harness/frameworks/gnark/circuits/toy/rangecheck"
+	"github.com/zkCollective/zk-Harness/frameworks/gnark/circuits/verifier"
 	"github.com/zkCollective/zk-Harness/frameworks/gnark/util"
 )
 
@@ -32,8 +32,23 @@ type BenchCircuit interface {
 	Witness(size int, curveID ecc.ID, name string, opts ...WitnessOption) witness.Witness
 }
 
+// Capabilities restricts where the registered circuits are benchmarked, a circuit without
+// an entry being benchmarked by the groth16 and plonk commands over every curve of the backends
+var Capabilities map[string]Capability
+
+// Capability declares the curves a circuit is benchmarked over, whether it commits and the command building it
+type Capability struct {
+	// Curves the witness is implemented over, every curve if empty
+	Curves []ecc.ID
+	// Commitments is set if the circuit calls api.Commit, directly or through the lookups and range checks of gnark/std
+	Commitments bool
+	// Command building the circuit around inner proofs, empty for the groth16 and plonk commands
+	Command string
+}
+
 func init() {
 	BenchCircuits = make(map[string]BenchCircuit)
+	Capabilities = make(map[string]Capability)
 
 	// Exponentiate Circuit
 	BenchCircuits["exponentiate"] = &defaultCircuit{}
@@ -43,13 +58,52 @@ func init() {
 	BenchCircuits["exponentiate_opt"] = &defaultCircuit{}
 	BenchCircuits["emulate"] = &defaultCircuit{}
 
+	// Range checks, by bit decomposition or with the commitment of std/rangecheck
+	BenchCircuits["rangecheck"] = &defaultCircuit{}
+	BenchCircuits["rangecheck_commit"] = &defaultCircuit{}
+
 	// Hashes
 	BenchCircuits["mimc"] = &defaultCircuit{}
 	BenchCircuits["sha2"] = &defaultCircuit{}
 
-	// Recursion
-	BenchCircuits["groth16_bls12377"] = &defaultCircuit{}
-	BenchCircuits["groth16_bls24315"] = &defaultCircuit{}
+	// the MiMC witness is hashed natively, over the curves of util.PreCalcMIMC
+	Capabilities["mimc"] = Capability{Curves: []ecc.ID{ecc.BN254, ecc.BLS12_381, ecc.BLS12_377, ecc.BLS24_315, ecc.BW6_761, ecc.BW6_633}}
+	// the range checks of the emulated arithmetic, of std/rangecheck and of the sha2 byte operations commit
+	Capabilities["emulate"] = Capability{Commitments: true}
+	Capabilities["rangecheck_commit"] = Capability{Commitments: true}
+	Capabilities["sha2"] = Capability{Commitments: true}
+
+	// Recursion and aggregation, named after the backend and curve of the inner proofs
+	verifierCircuits = make(map[string]verifierCircuit)
+	for _, v := range verifier.Verifiers {
+		registerVerifier(v.Name(), verifierCircuit{Verifier: v}, "recursion")
+		if !v.Aggregate {
+			continue
+		}
+		// the inner proofs are verified independently or, for PlonK, with a single pairing check
+		registerVerifier(v.Name()+"_aggregate", verifierCircuit{Verifier: v, aggregate: true}, "aggregate")
+		if v.Backend == "plonk" {
+			registerVerifier(v.Name()+"_aggregate_batched", verifierCircuit{Verifier: v, aggregate: true, batched: true}, "aggregate")
+		}
+	}
+}
+
+// verifierCircuits are the recursion and aggregation circuits, by name
+var verifierCircuits map[string]verifierCircuit
+
+// verifierCircuit verifies one inner proof, or the proofs of the aggregate command
+type verifierCircuit struct {
+	verifier.Verifier
+	aggregate bool
+	batched   bool
+}
+
+// registerVerifier registers a verifier circuit, which has no input of its own and is sized after the
+// inner circuit built by the command
+func registerVerifier(name string, v verifierCircuit, command string) {
+	BenchCircuits[name] = &defaultCircuit{}
+	Capabilities[name] = Capability{Command: command}
+	verifierCircuits[name] = v
 }
 
 type defaultCircuit struct {
@@ -93,6 +147,9 @@ func (d *defaultCircuit) Circuit(size int, name string, opts ...CircuitOption) f
 		return &exponentiate_opt.ExponentiateOptCircuit{}
 	case "emulate":
 		return &emulate.Circuit{}
+	case "rangecheck", "rangecheck_commit":
+		n, bits := rangeCheckInput(data)
+		return &rangecheck.RangeCheckCircuit{X: make([]frontend.Variable, n), Bits: bits, Commit: name == "rangecheck_commit"}
 	case "mimc":
 		return &mimc.MimcCircuit{}
 	case "sha2":
@@ -105,16 +162,16 @@ func (d *defaultCircuit) Circuit(size int, name string, opts ...CircuitOption) f
 			In: make([]uints.U8, len(bts)),
 		}
 		return result
-	case "groth16_bls12377":
-		outerCircuit := groth16bls12377verifier.VerifierCircuit{}
-		outerCircuit.InnerVk.Allocate(optCircuit.verifyingKey)
-		return &outerCircuit
-	case "groth16_bls24315":
-		outerCircuit := groth16bls24315verifier.VerifierCircuit{}
-		outerCircuit.InnerVk.Allocate(optCircuit.verifyingKey)
-		return &outerCircuit
 	default:
-		panic("not implemented")
+		v, ok := verifierCircuits[name]
+		if !ok {
+			panic("not implemented")
+		}
+		nbProofs := 1
+		if v.aggregate {
+			nbProofs = optCircuit.nbProofs
+		}
+		return v.Placeholder(optCircuit.innerCCS, nbProofs, v.batched)
 	}
 }
 
@@ -187,6 +244,12 @@ func (d *defaultCircuit) Witness(size int, curveID ecc.ID, name string, opts ...
 			panic(err)
 		}
 		return w
+	case "rangecheck", "rangecheck_commit":
+		w, err := frontend.NewWitness(rangecheck.Assignment(rangeCheckInput(data)), curveID.ScalarField())
+		if err != nil {
+			panic(err)
+		}
+		return w
 	case "mimc":
 		witness := mimc.MimcCircuit{}
 		witness.PreImage = (data["PreImage"].(string))
@@ -208,48 +271,59 @@ func (d *defaultCircuit) Witness(size int, curveID ecc.ID, name string, opts ...
 		witness := sha2.Sha2Circuit{
 			In: uints.NewU8Array(bts),
 		}
-		
+
 		copy(witness.Expected[:], uints.NewU8Array(dgst[:]))
 		w, err := frontend.NewWitness(&witness, curveID.ScalarField())
 		if err != nil {
 			panic(err)
 		}
 		return w
-	case "groth16_bls12377":
-		var outerAssignment groth16bls12377verifier.VerifierCircuit
-		outerAssignment.InnerProof.Assign(optWitness.proof)
-		outerAssignment.InnerVk.Assign(optWitness.verifyingKey)
-		outerAssignment.Witness = optWitness.witness
-		w, err := frontend.NewWitness(&outerAssignment, ecc.BW6_761.ScalarField())
+	default:
+		v, ok := verifierCircuits[name]
+		if !ok {
+			panic("not implemented")
+		}
+		proofs, witnesses := []interface{}{optWitness.proof}, []witness.Witness{optWitness.witness}
+		if v.aggregate {
+			proofs, witnesses = optWitness.proofs, optWitness.witnesses
+		}
+		outerAssignment, err := v.Assign(optWitness.verifyingKey, proofs, witnesses)
 		if err != nil {
 			panic(err)
 		}
-		return w
-	case "groth16_bls24315":
-		var outerAssignment groth16bls24315verifier.VerifierCircuit
-		outerAssignment.InnerProof.Assign(optWitness.proof)
-		outerAssignment.InnerVk.Assign(optWitness.verifyingKey)
-		outerAssignment.Witness = optWitness.witness
-		w, err := frontend.NewWitness(&outerAssignment, ecc.BW6_633.ScalarField())
+		w, err := frontend.NewWitness(outerAssignment, v.Outer.ScalarField())
 		if err != nil {
 			panic(err)
 		}
 		return w
-	default:
-		panic("not implemented")
 	}
 }
 
+// rangeCheckInput returns the number of values N and their width Bits of the input of the range check circuits
+func rangeCheckInput(data map[string]interface{}) (int, int) {
+	if data == nil || data["N"] == nil || data["Bits"] == nil {
+		panic("Input for N and Bits is not defined")
+	}
+	n, err := strconv.Atoi(data["N"].(string))
+	if err != nil {
+		panic(err)
+	}
+	bits, err := strconv.Atoi(data["Bits"].(string))
+	if err != nil {
+		panic(err)
+	}
+	return n, bits
+}
+
 // Optional Parameters Circuit
 type CircuitOption func(opt *CircuitConfig) error
 
 type CircuitConfig struct {
-	inputPath    string
-	verifyingKey groth16.VerifyingKey
+	inputPath string
+	innerCCS  constraint.ConstraintSystem
+	nbProofs  int
 }
 
-
-
 func WithInputCircuit(inputPath string) CircuitOption {
 	return func(opt *CircuitConfig) error {
 		opt.inputPath = inputPath
@@ -257,9 +331,18 @@ func WithInputCircuit(inputPath string) CircuitOption {
 	}
 }
 
-func WithVKCircuit(verifyingKey groth16.VerifyingKey) CircuitOption {
+// WithInnerCCSCircuit sizes the recursive verifier circuits after the inner constraint system
+func WithInnerCCSCircuit(innerCCS constraint.ConstraintSystem) CircuitOption {
 	return func(opt *CircuitConfig) error {
-		opt.verifyingKey = verifyingKey
+		opt.innerCCS = innerCCS
+		return nil
+	}
+}
+
+// WithNbProofsCircuit sizes the aggregation circuits after the number of inner proofs
+func WithNbProofsCircuit(nbProofs int) CircuitOption {
+	return func(opt *CircuitConfig) error {
+		opt.nbProofs = nbProofs
 		return nil
 	}
 }
@@ -269,9 +352,11 @@ type WitnessOption func(opt *WitnessConfig) error
 
 type WitnessConfig struct {
 	inputPath    string
-	proof        groth16.Proof
-	verifyingKey groth16.VerifyingKey
-	witness      frontend.Variable
+	proof        interface{}
+	verifyingKey interface{}
+	witness      witness.Witness
+	proofs       []interface{}
+	witnesses    []witness.Witness
 }
 
 func WithInputWitness(inputPath string) WitnessOption {
@@ -281,23 +366,35 @@ func WithInputWitness(inputPath string) WitnessOption {
 	}
 }
 
-func WithProof(proof groth16.Proof) WitnessOption {
+// WithProof sets the inner proof of the recursive verifier circuits
+func WithProof(proof interface{}) WitnessOption {
 	return func(opt *WitnessConfig) error {
 		opt.proof = proof
 		return nil
 	}
 }
 
-func WithVK(verifyingKey groth16.VerifyingKey) WitnessOption {
+// WithVK sets the inner verifying key of the recursive verifier circuits
+func WithVK(verifyingKey interface{}) WitnessOption {
 	return func(opt *WitnessConfig) error {
 		opt.verifyingKey = verifyingKey
 		return nil
 	}
 }
 
-func WithWitness(witness frontend.Variable) WitnessOption {
+// WithWitness sets the inner witness, whose public part is verified by the recursive verifier circuits
+func WithWitness(witness witness.Witness) WitnessOption {
 	return func(opt *WitnessConfig) error {
 		opt.witness = witness
 		return nil
 	}
 }
+
+// WithProofs sets the inner proofs and witnesses of the aggregation circuits
+func WithProofs(proofs []interface{}, witnesses []witness.Witness) WitnessOption {
+	return func(opt *WitnessConfig) error {
+		opt.proofs = proofs
+		opt.witnesses = witnesses
+		return nil
+	}
+}
@@ -19,5 +19,5 @@ func TestEmulatedArithmetic(t *testing.T) {
 	witness.Res = emulated.ValueOf[emulated.Secp256k1Fp]("485279052387156144224396168012515269674445015885648619762653195154800")
 
 	// Test over all curves, takes approx 30s
-	assert.ProverSucceeded(&circuit, &witness, test.NoSerialization())
+	assert.ProverSucceeded(&circuit, &witness, test.NoSerializationChecks())
 }
@@ -0,0 +1,54 @@
+package rangecheck
+
+import (
+	"math/big"
+
+	"github.com/consensys/gnark/frontend"
+	"github.com/consensys/gnark/std/rangecheck"
+)
+
+// RangeCheckCircuit defines a range check heavy circuit
+// X[i] < 2**Bits for all i and sum(X) == Sum
+type RangeCheckCircuit struct {
+	X   []frontend.Variable
+	Sum frontend.Variable `gnark:",public"`
+
+	// Bits is the width of the range of the values
+	Bits int `gnark:"-"`
+	// Commit checks the ranges with the log-derivative argument of std/rangecheck, which commits
+	// to the values with api.Commit, rather than by decomposing every value into bits
+	Commit bool `gnark:"-"`
+}
+
+// Define declares the circuit constraints
+// X[i] < 2**Bits for all i and sum(X) == Sum
+func (circuit *RangeCheckCircuit) Define(api frontend.API) error {
+	sum := frontend.Variable(0)
+	if circuit.Commit {
+		rc := rangecheck.New(api)
+		for _, x := range circuit.X {
+			rc.Check(x, circuit.Bits)
+			sum = api.Add(sum, x)
+		}
+	} else {
+		for _, x := range circuit.X {
+			api.ToBinary(x, circuit.Bits)
+			sum = api.Add(sum, x)
+		}
+	}
+	api.AssertIsEqual(circuit.Sum, sum)
+	return nil
+}
+
+// Assignment returns the witness of n values of the range, the i-th being i modulo 2**bits
+func Assignment(n int, bits int) *RangeCheckCircuit {
+	assignment := &RangeCheckCircuit{X: make([]frontend.Variable, n), Bits: bits}
+	sum := new(big.Int)
+	for i := range assignment.X {
+		x := uint64(i) & (1<<bits - 1)
+		assignment.X[i] = x
+		sum.Add(sum, new(big.Int).SetUint64(x))
+	}
+	assignment.Sum = sum
+	return assignment
+}
@@ -0,0 +1,27 @@
+package rangecheck
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/consensys/gnark-crypto/ecc"
+	"github.com/consensys/gnark/backend"
+	"github.com/consensys/gnark/frontend"
+	"github.com/consensys/gnark/test"
+)
+
+func TestRangeCheck(t *testing.T) {
+	assert := test.NewAssert(t)
+
+	for _, commit := range []bool{false, true} {
+		circuit := &RangeCheckCircuit{X: make([]frontend.Variable, 300), Bits: 8, Commit: commit}
+
+		assert.ProverSucceeded(circuit, Assignment(300, 8), test.WithCurves(ecc.BN254), test.WithBackends(backend.GROTH16, backend.PLONK))
+
+		// the sum holds but the first value is out of the range
+		wrong := Assignment(300, 8)
+		wrong.X[0] = 256
+		wrong.Sum = new(big.Int).Add(wrong.Sum.(*big.Int), big.NewInt(256))
+		assert.ProverFailed(circuit, wrong, test.WithCurves(ecc.BN254), test.WithBackends(backend.GROTH16, backend.PLONK))
+	}
+}
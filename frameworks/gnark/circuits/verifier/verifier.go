@@ -0,0 +1,199 @@
+// Package verifier implements the recursive Groth16 and PlonK verifier circuits of gnark's std/recursion,
+// generic over the curve of the inner proofs, and the table of the inner and outer curves they are benchmarked over.
+package verifier
+
+import (
+	"strings"
+
+	"github.com/consensys/gnark-crypto/ecc"
+	"github.com/consensys/gnark/backend/groth16"
+	"github.com/consensys/gnark/backend/plonk"
+	"github.com/consensys/gnark/backend/witness"
+	"github.com/consensys/gnark/constraint"
+	"github.com/consensys/gnark/frontend"
+	"github.com/consensys/gnark/std/algebra"
+	"github.com/consensys/gnark/std/algebra/emulated/sw_bls12381"
+	"github.com/consensys/gnark/std/algebra/emulated/sw_bn254"
+	"github.com/consensys/gnark/std/algebra/emulated/sw_bw6761"
+	"github.com/consensys/gnark/std/algebra/native/sw_bls12377"
+	"github.com/consensys/gnark/std/algebra/native/sw_bls24315"
+	"github.com/consensys/gnark/std/math/emulated"
+	stdgroth16 "github.com/consensys/gnark/std/recursion/groth16"
+	stdplonk "github.com/consensys/gnark/std/recursion/plonk"
+)
+
+// Verifiers are the verifier circuits of the recursion and aggregate commands: natively over the 2-chains,
+// with emulated arithmetic in BN254 otherwise, and over BN254 for the BW6_761 proofs of the recursion chains
+var Verifiers = []Verifier{
+	newGroth16[sw_bls12377.ScalarField, sw_bls12377.G1Affine, sw_bls12377.G2Affine, sw_bls12377.GT](ecc.BLS12_377, ecc.BW6_761, true),
+	newGroth16[sw_bls24315.ScalarField, sw_bls24315.G1Affine, sw_bls24315.G2Affine, sw_bls24315.GT](ecc.BLS24_315, ecc.BW6_633, true),
+	newGroth16[sw_bn254.ScalarField, sw_bn254.G1Affine, sw_bn254.G2Affine, sw_bn254.GTEl](ecc.BN254, ecc.BN254, true),
+	newGroth16[sw_bls12381.ScalarField, sw_bls12381.G1Affine, sw_bls12381.G2Affine, sw_bls12381.GTEl](ecc.BLS12_381, ecc.BN254, true),
+	newGroth16[sw_bw6761.ScalarField, sw_bw6761.G1Affine, sw_bw6761.G2Affine, sw_bw6761.GTEl](ecc.BW6_761, ecc.BN254, false),
+	newPlonk[sw_bls12377.ScalarField, sw_bls12377.G1Affine, sw_bls12377.G2Affine, sw_bls12377.GT](ecc.BLS12_377, ecc.BW6_761, true),
+	newPlonk[sw_bls24315.ScalarField, sw_bls24315.G1Affine, sw_bls24315.G2Affine, sw_bls24315.GT](ecc.BLS24_315, ecc.BW6_633, true),
+	newPlonk[sw_bn254.ScalarField, sw_bn254.G1Affine, sw_bn254.G2Affine, sw_bn254.GTEl](ecc.BN254, ecc.BN254, true),
+	newPlonk[sw_bls12381.ScalarField, sw_bls12381.G1Affine, sw_bls12381.G2Affine, sw_bls12381.GTEl](ecc.BLS12_381, ecc.BN254, true),
+	newPlonk[sw_bw6761.ScalarField, sw_bw6761.G1Affine, sw_bw6761.G2Affine, sw_bw6761.GTEl](ecc.BW6_761, ecc.BN254, false),
+}
+
+// Verifier verifies proofs of the Backend over the Inner curve in a circuit compiled over the Outer curve
+type Verifier struct {
+	Backend string
+	Inner   ecc.ID
+	Outer   ecc.ID
+	// Aggregate is set when the circuit is benchmarked by the aggregate command
+	Aggregate bool
+
+	placeholder func(innerCCS constraint.ConstraintSystem, nbProofs int, batched bool) frontend.Circuit
+	assign      func(innerVk interface{}, innerProofs []interface{}, innerWitnesses []witness.Witness) (frontend.Circuit, error)
+}
+
+// Name names the verifier circuit of the backend and inner curve, e.g. groth16_bls12377
+func Name(backend string, inner ecc.ID) string {
+	return backend + "_" + strings.ReplaceAll(strings.ToLower(inner.String()), "_", "")
+}
+
+// Name names the circuit verifying a single proof, the aggregation circuits being suffixed with _aggregate
+func (v Verifier) Name() string {
+	return Name(v.Backend, v.Inner)
+}
+
+// Placeholder allocates the circuit verifying nbProofs proofs of the inner constraint system, sized after
+// its public inputs and commitments. batched folds the KZG openings of PlonK proofs into a single pairing check
+func (v Verifier) Placeholder(innerCCS constraint.ConstraintSystem, nbProofs int, batched bool) frontend.Circuit {
+	return v.placeholder(innerCCS, nbProofs, batched)
+}
+
+// Assign assigns the inner proofs, their verifying key and the public part of the inner witnesses
+func (v Verifier) Assign(innerVk interface{}, innerProofs []interface{}, innerWitnesses []witness.Witness) (frontend.Circuit, error) {
+	return v.assign(innerVk, innerProofs, innerWitnesses)
+}
+
+// Groth16Circuit verifies proofs of the inner constraint system under the same verifying key
+type Groth16Circuit[FR emulated.FieldParams, G1El algebra.G1ElementT, G2El algebra.G2ElementT, GtEl algebra.GtElementT] struct {
+	InnerProofs    []stdgroth16.Proof[G1El, G2El]
+	InnerVk        stdgroth16.VerifyingKey[G1El, G2El, GtEl]
+	InnerWitnesses []stdgroth16.Witness[FR]
+}
+
+func (circuit *Groth16Circuit[FR, G1El, G2El, GtEl]) Define(api frontend.API) error {
+	verifier, err := stdgroth16.NewVerifier[FR, G1El, G2El, GtEl](api)
+	if err != nil {
+		return err
+	}
+	// Groth16 proofs are verified independently, each with its own pairing check
+	for i := range circuit.InnerProofs {
+		if err := verifier.AssertProof(circuit.InnerVk, circuit.InnerProofs[i], circuit.InnerWitnesses[i]); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func newGroth16[FR emulated.FieldParams, G1El algebra.G1ElementT, G2El algebra.G2ElementT, GtEl algebra.GtElementT](inner, outer ecc.ID, aggregate bool) Verifier {
+	return Verifier{
+		Backend:   "groth16",
+		Inner:     inner,
+		Outer:     outer,
+		Aggregate: aggregate,
+		placeholder: func(innerCCS constraint.ConstraintSystem, nbProofs int, _ bool) frontend.Circuit {
+			circuit := &Groth16Circuit[FR, G1El, G2El, GtEl]{
+				InnerProofs:    make([]stdgroth16.Proof[G1El, G2El], nbProofs),
+				InnerVk:        stdgroth16.PlaceholderVerifyingKey[G1El, G2El, GtEl](innerCCS),
+				InnerWitnesses: make([]stdgroth16.Witness[FR], nbProofs),
+			}
+			for i := 0; i < nbProofs; i++ {
+				circuit.InnerProofs[i] = stdgroth16.PlaceholderProof[G1El, G2El](innerCCS)
+				circuit.InnerWitnesses[i] = stdgroth16.PlaceholderWitness[FR](innerCCS)
+			}
+			return circuit
+		},
+		assign: func(innerVk interface{}, innerProofs []interface{}, innerWitnesses []witness.Witness) (frontend.Circuit, error) {
+			var err error
+			assignment := &Groth16Circuit[FR, G1El, G2El, GtEl]{
+				InnerProofs:    make([]stdgroth16.Proof[G1El, G2El], len(innerProofs)),
+				InnerWitnesses: make([]stdgroth16.Witness[FR], len(innerWitnesses)),
+			}
+			if assignment.InnerVk, err = stdgroth16.ValueOfVerifyingKey[G1El, G2El, GtEl](innerVk.(groth16.VerifyingKey)); err != nil {
+				return nil, err
+			}
+			for i := range innerProofs {
+				if assignment.InnerProofs[i], err = stdgroth16.ValueOfProof[G1El, G2El](innerProofs[i].(groth16.Proof)); err != nil {
+					return nil, err
+				}
+				if assignment.InnerWitnesses[i], err = stdgroth16.ValueOfWitness[FR](innerWitnesses[i]); err != nil {
+					return nil, err
+				}
+			}
+			return assignment, nil
+		},
+	}
+}
+
+// PlonkCircuit verifies proofs of the inner constraint system under the same verifying key
+type PlonkCircuit[FR emulated.FieldParams, G1El algebra.G1ElementT, G2El algebra.G2ElementT, GtEl algebra.GtElementT] struct {
+	InnerProofs    []stdplonk.Proof[FR, G1El, G2El]
+	InnerVk        stdplonk.VerifyingKey[FR, G1El, G2El]
+	InnerWitnesses []stdplonk.Witness[FR]
+
+	// Batched folds the KZG openings of all proofs into a single pairing check
+	Batched bool `gnark:"-"`
+}
+
+func (circuit *PlonkCircuit[FR, G1El, G2El, GtEl]) Define(api frontend.API) error {
+	verifier, err := stdplonk.NewVerifier[FR, G1El, G2El, GtEl](api)
+	if err != nil {
+		return err
+	}
+	if circuit.Batched {
+		return verifier.AssertSameProofs(circuit.InnerVk, circuit.InnerProofs, circuit.InnerWitnesses)
+	}
+	for i := range circuit.InnerProofs {
+		if err := verifier.AssertProof(circuit.InnerVk, circuit.InnerProofs[i], circuit.InnerWitnesses[i]); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func newPlonk[FR emulated.FieldParams, G1El algebra.G1ElementT, G2El algebra.G2ElementT, GtEl algebra.GtElementT](inner, outer ecc.ID, aggregate bool) Verifier {
+	return Verifier{
+		Backend:   "plonk",
+		Inner:     inner,
+		Outer:     outer,
+		Aggregate: aggregate,
+		placeholder: func(innerCCS constraint.ConstraintSystem, nbProofs int, batched bool) frontend.Circuit {
+			circuit := &PlonkCircuit[FR, G1El, G2El, GtEl]{
+				InnerProofs:    make([]stdplonk.Proof[FR, G1El, G2El], nbProofs),
+				InnerVk:        stdplonk.PlaceholderVerifyingKey[FR, G1El, G2El](innerCCS),
+				InnerWitnesses: make([]stdplonk.Witness[FR], nbProofs),
+				Batched:        batched,
+			}
+			for i := 0; i < nbProofs; i++ {
+				circuit.InnerProofs[i] = stdplonk.PlaceholderProof[FR, G1El, G2El](innerCCS)
+				circuit.InnerWitnesses[i] = stdplonk.PlaceholderWitness[FR](innerCCS)
+			}
+			return circuit
+		},
+		assign: func(innerVk interface{}, innerProofs []interface{}, innerWitnesses []witness.Witness) (frontend.Circuit, error) {
+			var err error
+			assignment := &PlonkCircuit[FR, G1El, G2El, GtEl]{
+				InnerProofs:    make([]stdplonk.Proof[FR, G1El, G2El], len(innerProofs)),
+				InnerWitnesses: make([]stdplonk.Witness[FR], len(innerWitnesses)),
+			}
+			if assignment.InnerVk, err = stdplonk.ValueOfVerifyingKey[FR, G1El, G2El](innerVk.(plonk.VerifyingKey)); err != nil {
+				return nil, err
+			}
+			for i := range innerProofs {
+				if assignment.InnerProofs[i], err = stdplonk.ValueOfProof[FR, G1El, G2El](innerProofs[i].(plonk.Proof)); err != nil {
+					return nil, err
+				}
+				if assignment.InnerWitnesses[i], err = stdplonk.ValueOfWitness[FR](innerWitnesses[i]); err != nil {
+					return nil, err
+				}
+			}
+			return assignment, nil
+		},
+	}
+}
@@ -0,0 +1,226 @@
+package verifier
+
+import (
+	"crypto/sha256"
+	"testing"
+
+	"github.com/consensys/gnark-crypto/ecc"
+	"github.com/consensys/gnark/backend"
+	"github.com/consensys/gnark/backend/groth16"
+	"github.com/consensys/gnark/backend/plonk"
+	"github.com/consensys/gnark/backend/witness"
+	"github.com/consensys/gnark/constraint"
+	"github.com/consensys/gnark/frontend"
+	"github.com/consensys/gnark/frontend/cs/r1cs"
+	"github.com/consensys/gnark/frontend/cs/scs"
+	"github.com/consensys/gnark/std/math/uints"
+	stdgroth16 "github.com/consensys/gnark/std/recursion/groth16"
+	stdplonk "github.com/consensys/gnark/std/recursion/plonk"
+	"github.com/consensys/gnark/test"
+	"github.com/consensys/gnark/test/unsafekzg"
+	mimc "github.com/zkCollective/zk-Harness/frameworks/gnark/circuits/prf/mimc"
+	"github.com/zkCollective/zk-Harness/frameworks/gnark/circuits/prf/sha2"
+	"github.com/zkCollective/zk-Harness/frameworks/gnark/util"
+)
+
+const (
+	preImage = "4992816046196248432836492760315135318126925090839638585255611512962528270024"
+)
+
+// lookup returns the verifier of the table for the backend and inner curve
+func lookup(t *testing.T, backend string, inner ecc.ID) Verifier {
+	for _, v := range Verifiers {
+		if v.Backend == backend && v.Inner == inner {
+			return v
+		}
+	}
+	t.Fatalf("no %s verifier of %s proofs", backend, inner)
+	return Verifier{}
+}
+
+// proveInner proves every assignment of the inner circuit with the backend over the inner curve, under the
+// same verifying key, for verification in a circuit over the outer curve
+func proveInner(t *testing.T, backend string, inner, outer ecc.ID, circuit frontend.Circuit, assignments ...frontend.Circuit) (constraint.ConstraintSystem, interface{}, []interface{}, []witness.Witness) {
+	innerField, outerField := inner.ScalarField(), outer.ScalarField()
+
+	var (
+		ccs     constraint.ConstraintSystem
+		err     error
+		innerVk interface{}
+		prove   func(witness.Witness) (interface{}, error)
+		verify  func(interface{}, witness.Witness) error
+	)
+	switch backend {
+	case "groth16":
+		if ccs, err = frontend.Compile(innerField, r1cs.NewBuilder, circuit); err != nil {
+			t.Fatal(err)
+		}
+		innerPk, vk, err := groth16.Setup(ccs)
+		if err != nil {
+			t.Fatal(err)
+		}
+		innerVk = vk
+		prove = func(w witness.Witness) (interface{}, error) {
+			return groth16.Prove(ccs, innerPk, w, stdgroth16.GetNativeProverOptions(outerField, innerField))
+		}
+		verify = func(proof interface{}, publicWitness witness.Witness) error {
+			return groth16.Verify(proof.(groth16.Proof), vk, publicWitness, stdgroth16.GetNativeVerifierOptions(outerField, innerField))
+		}
+	case "plonk":
+		if ccs, err = frontend.Compile(innerField, scs.NewBuilder, circuit); err != nil {
+			t.Fatal(err)
+		}
+		srs, srsLagrange, err := unsafekzg.NewSRS(ccs)
+		if err != nil {
+			t.Fatal(err)
+		}
+		innerPk, vk, err := plonk.Setup(ccs, srs, srsLagrange)
+		if err != nil {
+			t.Fatal(err)
+		}
+		innerVk = vk
+		prove = func(w witness.Witness) (interface{}, error) {
+			return plonk.Prove(ccs, innerPk, w, stdplonk.GetNativeProverOptions(outerField, innerField))
+		}
+		verify = func(proof interface{}, publicWitness witness.Witness) error {
+			return plonk.Verify(proof.(plonk.Proof), vk, publicWitness, stdplonk.GetNativeVerifierOptions(outerField, innerField))
+		}
+	}
+
+	proofs := make([]interface{}, len(assignments))
+	witnesses := make([]witness.Witness, len(assignments))
+	for i, assignment := range assignments {
+		witness, err := frontend.NewWitness(assignment, innerField)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		proof, err := prove(witness)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		publicWitness, err := witness.Public()
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		// Check that proof verifies before continuing
+		if err := verify(proof, publicWitness); err != nil {
+			t.Fatal(err)
+		}
+		proofs[i], witnesses[i] = proof, witness
+	}
+
+	return ccs, innerVk, proofs, witnesses
+}
+
+// TestVerifiers verifies nbProofs inner mimc proofs with every verifier of the table, the single proofs
+// of the recursion command and the aggregations of two proofs, independently or with a single pairing check.
+// The verifier circuits are solved in the test engine, proving them takes minutes, except for the Groth16
+// 2-chains which are also proven. With -short, only the verifiers over the 2-chains are solved, the emulated
+// verifiers over BN254 taking minutes to solve as well.
+func TestVerifiers(t *testing.T) {
+	for _, tc := range []struct {
+		backend  string
+		inner    ecc.ID
+		outer    ecc.ID
+		nbProofs int
+		batched  bool
+		prove    bool
+	}{
+		{"groth16", ecc.BLS12_377, ecc.BW6_761, 1, false, true},
+		{"groth16", ecc.BLS12_377, ecc.BW6_761, 2, false, false},
+		{"groth16", ecc.BLS24_315, ecc.BW6_633, 1, false, true},
+		{"groth16", ecc.BLS24_315, ecc.BW6_633, 2, false, false},
+		{"groth16", ecc.BN254, ecc.BN254, 1, false, false},
+		{"groth16", ecc.BN254, ecc.BN254, 2, false, false},
+		{"groth16", ecc.BLS12_381, ecc.BN254, 1, false, false},
+		{"groth16", ecc.BLS12_381, ecc.BN254, 2, false, false},
+		{"groth16", ecc.BW6_761, ecc.BN254, 1, false, false},
+		{"plonk", ecc.BLS12_377, ecc.BW6_761, 1, false, false},
+		{"plonk", ecc.BLS12_377, ecc.BW6_761, 2, false, false},
+		{"plonk", ecc.BLS12_377, ecc.BW6_761, 2, true, false},
+		{"plonk", ecc.BLS24_315, ecc.BW6_633, 1, false, false},
+		{"plonk", ecc.BLS24_315, ecc.BW6_633, 2, false, false},
+		{"plonk", ecc.BLS24_315, ecc.BW6_633, 2, true, false},
+		{"plonk", ecc.BN254, ecc.BN254, 1, false, false},
+		{"plonk", ecc.BN254, ecc.BN254, 2, false, false},
+		{"plonk", ecc.BN254, ecc.BN254, 2, true, false},
+		{"plonk", ecc.BLS12_381, ecc.BN254, 1, false, false},
+		{"plonk", ecc.BLS12_381, ecc.BN254, 2, false, false},
+		{"plonk", ecc.BLS12_381, ecc.BN254, 2, true, false},
+		{"plonk", ecc.BW6_761, ecc.BN254, 1, false, false},
+	} {
+		v := lookup(t, tc.backend, tc.inner)
+		name := v.Name()
+		if tc.nbProofs > 1 {
+			name += "_aggregate"
+		}
+		if tc.batched {
+			name += "_batched"
+		}
+		t.Run(name, func(t *testing.T) {
+			if testing.Short() && tc.outer == ecc.BN254 {
+				t.Skip("solving the emulated verifiers takes minutes")
+			}
+			assert := test.NewAssert(t)
+			assert.Equal(tc.outer, v.Outer, "outer curve")
+			if tc.nbProofs > 1 {
+				assert.True(v.Aggregate, "aggregation")
+			}
+
+			// create mock cs: knowing the preimages of hashes using mimc
+			preImages := []string{preImage, "42"}[:tc.nbProofs]
+			assignments := make([]frontend.Circuit, len(preImages))
+			for i := range preImages {
+				assignments[i] = &mimc.MimcCircuit{PreImage: preImages[i], Hash: util.PreCalcMIMC(tc.inner, preImages[i])}
+			}
+
+			ccs, innerVk, proofs, witnesses := proveInner(t, tc.backend, tc.inner, tc.outer, &mimc.MimcCircuit{}, assignments...)
+
+			outerAssignment, err := v.Assign(innerVk, proofs, witnesses)
+			assert.NoError(err)
+
+			if tc.prove && !testing.Short() {
+				assert.ProverSucceeded(v.Placeholder(ccs, tc.nbProofs, tc.batched), outerAssignment, test.WithCurves(tc.outer), test.WithBackends(backend.GROTH16))
+			} else {
+				assert.NoError(test.IsSolved(v.Placeholder(ccs, tc.nbProofs, tc.batched), outerAssignment, tc.outer.ScalarField()))
+			}
+		})
+	}
+}
+
+// TestRecursionPublicInputs verifies an inner sha2 proof over the 2-chains, with the 32 bytes of the digest
+// as public inputs and a commitment from the range checks of the byte operations
+func TestRecursionPublicInputs(t *testing.T) {
+	if testing.Short() {
+		t.Skip("proving the verifier circuits takes minutes")
+	}
+	bts := []byte("hello world")
+	dgst := sha256.Sum256(bts)
+
+	assignment := sha2.Sha2Circuit{
+		In: uints.NewU8Array(bts),
+	}
+	copy(assignment.Expected[:], uints.NewU8Array(dgst[:]))
+
+	for _, inner := range []ecc.ID{ecc.BLS12_377, ecc.BLS24_315} {
+		v := lookup(t, "groth16", inner)
+		t.Run(v.Name(), func(t *testing.T) {
+			ccs, innerVk, proofs, witnesses := proveInner(t, v.Backend, v.Inner, v.Outer, &sha2.Sha2Circuit{In: make([]uints.U8, len(bts))}, &assignment)
+			if ccs.GetNbPublicVariables() != 33 {
+				t.Fatalf("expected 32 public inputs, got %d", ccs.GetNbPublicVariables()-1)
+			}
+
+			outerAssignment, err := v.Assign(innerVk, proofs, witnesses)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			assert := test.NewAssert(t)
+
+			assert.ProverSucceeded(v.Placeholder(ccs, 1, false), outerAssignment, test.WithCurves(v.Outer), test.WithBackends(backend.GROTH16))
+		})
+	}
+}
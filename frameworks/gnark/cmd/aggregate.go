@@ -0,0 +1,103 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"strconv"
+
+	"github.com/consensys/gnark/backend/witness"
+	"github.com/consensys/gnark/constraint"
+	"github.com/consensys/gnark/logger"
+	"github.com/spf13/cobra"
+	"github.com/zkCollective/zk-Harness/frameworks/gnark/circuits/verifier"
+	"github.com/zkCollective/zk-Harness/frameworks/gnark/parser"
+	"github.com/zkCollective/zk-Harness/frameworks/gnark/util"
+)
+
+// aggregateCmd represents the aggregate command
+var aggregateCmd = &cobra.Command{
+	Use:   "aggregate",
+	Short: "runs benchmarks for the aggregation of --proofs inner proofs in one outer circuit",
+	Run:   runAggregate,
+}
+
+var (
+	fAggregation *string
+	fProofs      *int
+)
+
+func runAggregate(cmd *cobra.Command, args []string) {
+	log := logger.Logger()
+	log.Info().Msg("Benchmarking " + *cfg.Circuit + " - gnark, aggregation of " + strconv.Itoa(*fProofs) + " " + *cfg.InnerBackend + " proofs in " + *cfg.OuterBackend + ": " + *cfg.Algo + " " + *cfg.Curve + " " + *cfg.InputPath)
+
+	var filename = *cfg.OutputPath
+
+	if err := parser.ParseFlagsRecursion(cfg); err != nil {
+		fmt.Println("error: ", err.Error())
+		cmd.Help()
+		os.Exit(-1)
+	}
+	if *cfg.Depth > 1 {
+		fmt.Println("error: aggregation does not support recursion chains")
+		cmd.Help()
+		os.Exit(-1)
+	}
+	if *fProofs < 1 {
+		fmt.Println("error: the number of proofs must be >= 1")
+		cmd.Help()
+		os.Exit(-1)
+	}
+
+	// the aggregation circuits are named after the verifier circuits, e.g. plonk_bls12377_aggregate_batched
+	suffix := "_aggregate"
+	switch *fAggregation {
+	case "independent":
+	case "batched":
+		if *cfg.InnerBackend != "plonk" {
+			fmt.Println("error: batched aggregation requires the plonk inner backend")
+			cmd.Help()
+			os.Exit(-1)
+		}
+		suffix += "_batched"
+	default:
+		fmt.Println("error: invalid aggregation, must be independent or batched")
+		cmd.Help()
+		os.Exit(-1)
+	}
+	nbProofs := *fProofs
+	aggregationCircuit := verifier.Name(*cfg.InnerBackend, parser.InnerCurveID) + suffix
+
+	// pre-compute the inner proofs under the same verifying key
+	innerVk, innerProofs, innerCCS, innerWitness := computeInnerProofs(nbProofs, *cfg.InnerBackend)
+	innerWitnesses := make([]witness.Witness, nbProofs)
+	for i := range innerWitnesses {
+		innerWitnesses[i] = innerWitness
+	}
+
+	var nativeCCS constraint.ConstraintSystem
+	if parser.Emulated {
+		nativeCCS = compileNativeVerifier(*cfg.CircuitSize, innerCCS, *cfg.InnerBackend, *cfg.OuterBackend, suffix, nbProofs)
+	}
+
+	writeResults := recursionWriter(filename, "aggregation", innerCCS, nativeCCS, nbProofs, *fAggregation)
+
+	opts := []util.BenchOption{
+		util.WithVK(innerVk),
+		util.WithProofs(innerProofs, innerWitnesses),
+		util.WithInnerCCS(innerCCS),
+	}
+
+	switch *cfg.OuterBackend {
+	case "groth16":
+		benchGroth16(writeResults, *cfg.Algo, *cfg.Count, *cfg.CircuitSize, aggregationCircuit, opts...)
+	case "plonk":
+		benchPlonk(writeResults, *cfg.Algo, *cfg.Count, *cfg.CircuitSize, aggregationCircuit, opts...)
+	}
+}
+
+func init() {
+	fProofs = aggregateCmd.Flags().Int("proofs", 2, "number of inner proofs aggregated in the outer circuit, proven once and sharing the inner witness")
+	fAggregation = aggregateCmd.Flags().String("aggregation", "independent", "verification of the inner proofs. must be independent or batched (plonk inner backend only)")
+
+	rootCmd.AddCommand(aggregateCmd)
+}
@@ -0,0 +1,208 @@
+package cmd
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"math/bits"
+	"os"
+	"strconv"
+	"time"
+
+	"github.com/consensys/gnark-crypto/ecc"
+	"github.com/consensys/gnark/backend/groth16"
+	groth16_bn254 "github.com/consensys/gnark/backend/groth16/bn254"
+	"github.com/consensys/gnark/backend/groth16/bn254/mpcsetup"
+	"github.com/consensys/gnark/constraint"
+	cs_bn254 "github.com/consensys/gnark/constraint/bn254"
+	"github.com/consensys/gnark/frontend"
+	"github.com/consensys/gnark/frontend/cs/r1cs"
+	"github.com/consensys/gnark/logger"
+	"github.com/spf13/cobra"
+	"github.com/zkCollective/zk-Harness/frameworks/gnark/circuits"
+	"github.com/zkCollective/zk-Harness/frameworks/gnark/parser"
+	"github.com/zkCollective/zk-Harness/frameworks/gnark/util"
+)
+
+// ceremonyCmd represents the ceremony command
+var ceremonyCmd = &cobra.Command{
+	Use:   "ceremony",
+	Short: "simulates a Groth16 MPC setup ceremony over bn254 and benchmarks every contribution and verification",
+	Run:   runCeremony,
+}
+
+var (
+	fContributors *int
+	fCeremonyKeys *string
+)
+
+func runCeremony(cmd *cobra.Command, args []string) {
+	log := logger.Logger()
+	log.Info().Msg("Benchmarking " + *cfg.Circuit + " - gnark, groth16 ceremony: " + *cfg.Curve + " " + *cfg.InputPath)
+
+	*cfg.Backend = "groth16"
+	if err := parser.ParseFlags(cfg); err != nil {
+		fmt.Println("error: ", err.Error())
+		cmd.Help()
+		os.Exit(-1)
+	}
+	if parser.CurveID != ecc.BN254 {
+		fmt.Println("error: the ceremony is only implemented over bn254")
+		os.Exit(-1)
+	}
+	if *fContributors < 1 {
+		fmt.Println("error: a ceremony needs at least one contributor")
+		cmd.Help()
+		os.Exit(-1)
+	}
+
+	ccs, err := frontend.Compile(
+		parser.CurveID.ScalarField(),
+		r1cs.NewBuilder,
+		parser.C.Circuit(*cfg.CircuitSize, *cfg.Circuit, circuits.WithInputCircuit(*cfg.InputPath)),
+		frontend.WithCapacity(*cfg.CircuitSize),
+		frontend.IgnoreUnconstrainedInputs())
+	assertNoError(err)
+	if util.NbCommitments(ccs) > 0 {
+		fmt.Println("error: the ceremony does not support circuits with commitments")
+		os.Exit(-1)
+	}
+
+	// the powers of tau span the evaluation domain of the prover
+	power := bits.TrailingZeros64(ecc.NextPowerOfTwo(uint64(ccs.GetNbConstraints())))
+
+	write := func(operation string, contributor int, took time.Duration, size int) {
+		bData := util.BenchDataCeremony{
+			Framework:     "gnark",
+			Category:      "ceremony",
+			Backend:       "groth16",
+			Curve:         parser.CurveID.String(),
+			Circuit:       *cfg.Circuit,
+			Input:         *cfg.InputPath,
+			Operation:     operation,
+			Contributor:   contributor,
+			NbConstraints: ccs.GetNbConstraints(),
+			Power:         power,
+			Size:          size,
+			RunTime:       took.Microseconds(),
+		}
+		if err := util.WriteData("csv", bData, *cfg.OutputPath); err != nil {
+			panic(err)
+		}
+	}
+
+	pk, vk := ceremony(ccs.(*cs_bn254.R1CS), power, *fContributors, write)
+
+	// the extracted keys must prove and verify before being handed to the prove and verify benchmarks
+	assertNoError(checkKeys(ccs, &pk, &vk))
+	assertNoError(os.MkdirAll(*fCeremonyKeys, 0755))
+	assertNoError(writeArtifact(*fCeremonyKeys, "pk.dat", &pk))
+	assertNoError(writeArtifact(*fCeremonyKeys, "vk.dat", &vk))
+}
+
+// ceremony runs both phases of the ceremony of the circuit with 2^power powers of tau and the number of
+// contributors, every contribution received serialized, and returns the extracted keys.
+// Every operation is timed and written.
+func ceremony(ccs *cs_bn254.R1CS, power int, contributors int, write func(operation string, contributor int, took time.Duration, size int)) (groth16_bn254.ProvingKey, groth16_bn254.VerifyingKey) {
+	fmt.Println("BENCHMARK CEREMONY PHASE 1 WITH " + strconv.Itoa(contributors) + " CONTRIBUTORS")
+	start := time.Now()
+	srs1 := mpcsetup.InitPhase1(power)
+	write("phase1_init", 0, time.Since(start), util.SerializedSize(&srs1))
+
+	for i := 1; i <= contributors; i++ {
+		var next mpcsetup.Phase1
+		_, err := receiveContribution(&next, &srs1)
+		assertNoError(err)
+
+		start = time.Now()
+		next.Contribute()
+		write("phase1_contribute", i, time.Since(start), util.SerializedSize(&next))
+
+		start = time.Now()
+		assertNoError(mpcsetup.VerifyPhase1(&srs1, &next))
+		write("phase1_verify", i, time.Since(start), 0)
+		srs1 = next
+	}
+
+	fmt.Println("BENCHMARK CEREMONY PHASE 2 WITH " + strconv.Itoa(contributors) + " CONTRIBUTORS")
+	start = time.Now()
+	srs2, evals := mpcsetup.InitPhase2(ccs, &srs1)
+	write("phase2_init", 0, time.Since(start), util.SerializedSize(&srs2))
+
+	for i := 1; i <= contributors; i++ {
+		var next mpcsetup.Phase2
+		_, err := receiveContribution(&next, &srs2)
+		assertNoError(err)
+
+		start = time.Now()
+		next.Contribute()
+		write("phase2_contribute", i, time.Since(start), util.SerializedSize(&next))
+
+		start = time.Now()
+		assertNoError(mpcsetup.VerifyPhase2(&srs2, &next))
+		write("phase2_verify", i, time.Since(start), 0)
+		srs2 = next
+	}
+
+	start = time.Now()
+	pk, vk := mpcsetup.ExtractKeys(&srs1, &srs2, &evals, ccs.GetNbConstraints())
+	write("extract", 0, time.Since(start), util.SerializedSize(&pk))
+	return pk, vk
+}
+
+// receiveContribution sets dst to the state of the ceremony src as serialized for the next contributor
+func receiveContribution(dst io.ReaderFrom, src io.WriterTo) (int64, error) {
+	var buf bytes.Buffer
+	if _, err := src.WriteTo(&buf); err != nil {
+		return 0, err
+	}
+	return dst.ReadFrom(&buf)
+}
+
+// checkKeys proves the circuit on its input with the keys and verifies the proof
+func checkKeys(ccs constraint.ConstraintSystem, pk groth16.ProvingKey, vk groth16.VerifyingKey) error {
+	witness := parser.C.Witness(*cfg.CircuitSize, parser.CurveID, *cfg.Circuit, circuits.WithInputWitness(*cfg.InputPath))
+	proof, err := groth16.Prove(ccs, pk, witness)
+	if err != nil {
+		return err
+	}
+	publicWitness, err := witness.Public()
+	if err != nil {
+		return err
+	}
+	if err := groth16.Verify(proof, vk, publicWitness); err != nil {
+		return errors.New("the keys of the ceremony do not verify: " + err.Error())
+	}
+	return nil
+}
+
+// groth16Keys returns the keys of the circuit, read from the pk.dat and vk.dat in keysDir if set,
+// e.g. extracted by the ceremony command, or from a single party setup otherwise
+func groth16Keys(ccs constraint.ConstraintSystem, keysDir string) (groth16.ProvingKey, groth16.VerifyingKey) {
+	if keysDir == "" || keysDir == "none" {
+		pk, vk, err := groth16.Setup(ccs)
+		assertNoError(err)
+		return pk, vk
+	}
+	pk, vk := groth16.NewProvingKey(parser.CurveID), groth16.NewVerifyingKey(parser.CurveID)
+	assertNoError(readArtifact(keysDir, "pk.dat", pk))
+	assertNoError(readArtifact(keysDir, "vk.dat", vk))
+	mismatch := vk.NbPublicWitness() != ccs.GetNbPublicVariables()-1
+	// a bn254 proving key, e.g. of the ceremony, has an entry per wire of the circuit
+	if pk, ok := pk.(*groth16_bn254.ProvingKey); ok {
+		internal, secret, public := ccs.GetNbVariables()
+		mismatch = mismatch || len(pk.InfinityA) != internal+secret+public
+	}
+	if mismatch {
+		panic("the keys in " + keysDir + " are not of the circuit")
+	}
+	return pk, vk
+}
+
+func init() {
+	fContributors = ceremonyCmd.Flags().Int("contributors", 3, "number of contributors to each phase of the ceremony")
+	fCeremonyKeys = ceremonyCmd.Flags().String("keys", "tmp/ceremony", "directory the extracted pk.dat and vk.dat are written to")
+
+	rootCmd.AddCommand(ceremonyCmd)
+}
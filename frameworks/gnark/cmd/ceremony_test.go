@@ -0,0 +1,70 @@
+package cmd
+
+import (
+	"os"
+	"testing"
+	"time"
+
+	"github.com/consensys/gnark-crypto/ecc"
+	"github.com/consensys/gnark/backend/groth16"
+	groth16_bn254 "github.com/consensys/gnark/backend/groth16/bn254"
+	cs_bn254 "github.com/consensys/gnark/constraint/bn254"
+	"github.com/consensys/gnark/frontend"
+	"github.com/consensys/gnark/frontend/cs/r1cs"
+	"github.com/consensys/gnark/test"
+	"github.com/zkCollective/zk-Harness/frameworks/gnark/circuits"
+	"github.com/zkCollective/zk-Harness/frameworks/gnark/parser"
+)
+
+func TestCeremony(t *testing.T) {
+	assert := test.NewAssert(t)
+
+	// the inputs are resolved from the gnark directory, as by the harness
+	wd, err := os.Getwd()
+	assert.NoError(err)
+	assert.NoError(os.Chdir(".."))
+	t.Cleanup(func() { os.Chdir(wd) })
+
+	*cfg.Circuit, *cfg.Curve, *cfg.Algo, *cfg.Backend = "cubic", "bn254", "setup", "groth16"
+	*cfg.InputPath = "input/circuit/cubic/input_1.json"
+	*cfg.CircuitSize = 1
+	assert.NoError(parser.ParseFlags(cfg))
+	ccs, err := frontend.Compile(ecc.BN254.ScalarField(), r1cs.NewBuilder,
+		parser.C.Circuit(*cfg.CircuitSize, *cfg.Circuit, circuits.WithInputCircuit(*cfg.InputPath)))
+	assert.NoError(err)
+
+	var operations []string
+	write := func(operation string, contributor int, took time.Duration, size int) {
+		operations = append(operations, operation)
+	}
+	pk, vk := ceremony(ccs.(*cs_bn254.R1CS), 2, 2, write)
+	assert.Equal([]string{
+		"phase1_init", "phase1_contribute", "phase1_verify", "phase1_contribute", "phase1_verify",
+		"phase2_init", "phase2_contribute", "phase2_verify", "phase2_contribute", "phase2_verify",
+		"extract",
+	}, operations)
+	assert.NoError(checkKeys(ccs, &pk, &vk))
+
+	// the verifying key of another setup does not verify the proofs of the ceremony
+	_, otherVk, err := groth16.Setup(ccs)
+	assert.NoError(err)
+	assert.Error(checkKeys(ccs, &pk, otherVk))
+
+	// the keys written by the ceremony are read back by the prove and verify benchmarks
+	dir := t.TempDir()
+	assert.NoError(writeArtifact(dir, "pk.dat", &pk))
+	assert.NoError(writeArtifact(dir, "vk.dat", &vk))
+	readPk, readVk := groth16Keys(ccs, dir)
+	assert.Equal(&vk, readVk.(*groth16_bn254.VerifyingKey))
+	assert.Equal(pk.G1.A, readPk.(*groth16_bn254.ProvingKey).G1.A)
+	assert.NoError(checkKeys(ccs, readPk, readVk))
+
+	// a circuit with another number of wires rejects the keys
+	*cfg.Circuit = "exponentiate"
+	*cfg.InputPath = "input/circuit/exponentiate/input_10.json"
+	assert.NoError(parser.ParseFlags(cfg))
+	other, err := frontend.Compile(ecc.BN254.ScalarField(), r1cs.NewBuilder,
+		parser.C.Circuit(*cfg.CircuitSize, *cfg.Circuit, circuits.WithInputCircuit(*cfg.InputPath)))
+	assert.NoError(err)
+	assert.Panics(func() { groth16Keys(other, dir) })
+}
@@ -0,0 +1,60 @@
+package cmd
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"os"
+	"os/signal"
+	"time"
+
+	"github.com/consensys/gnark/logger"
+	"github.com/spf13/cobra"
+	"github.com/zkCollective/zk-Harness/frameworks/gnark/service"
+)
+
+// coordinatorCmd represents the coordinator command
+var coordinatorCmd = &cobra.Command{
+	Use:   "coordinator",
+	Short: "serves the benchmark specs of input/config/gnark to a pool of workers and merges their results",
+	Run:   runCoordinator,
+}
+
+var (
+	fCoordinatorAddr  *string
+	fCoordinatorLease *time.Duration
+)
+
+func runCoordinator(cmd *cobra.Command, args []string) {
+	log := logger.Logger()
+
+	if *fCoordinatorLease < time.Second {
+		fmt.Println("error: the lease must last at least 1s")
+		cmd.Help()
+		os.Exit(-1)
+	}
+
+	// the input paths of the specs are relative to the root of the repository, as for --input
+	c := service.NewCoordinator("../../", *fCoordinatorLease)
+	server := &http.Server{Addr: *fCoordinatorAddr, Handler: c.Handler()}
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt)
+	defer stop()
+	go func() {
+		<-ctx.Done()
+		server.Shutdown(context.Background())
+	}()
+
+	log.Info().Msg("Coordinating benchmark workers on " + *fCoordinatorAddr)
+	if err := server.ListenAndServe(); !errors.Is(err, http.ErrServerClosed) {
+		assertNoError(err)
+	}
+}
+
+func init() {
+	fCoordinatorAddr = coordinatorCmd.Flags().String("addr", ":8081", "address the HTTP API listens on")
+	fCoordinatorLease = coordinatorCmd.Flags().Duration("lease", time.Minute, "time after which the cell of a worker without heartbeat is leased to another worker")
+
+	rootCmd.AddCommand(coordinatorCmd)
+}
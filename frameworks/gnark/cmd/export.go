@@ -0,0 +1,107 @@
+package cmd
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/consensys/gnark/constraint"
+	"github.com/consensys/gnark/frontend"
+	"github.com/consensys/gnark/frontend/cs/r1cs"
+	"github.com/consensys/gnark/frontend/cs/scs"
+	"github.com/consensys/gnark/logger"
+	"github.com/spf13/cobra"
+	"github.com/zkCollective/zk-Harness/frameworks/gnark/circuits"
+	"github.com/zkCollective/zk-Harness/frameworks/gnark/circuits/circom"
+	"github.com/zkCollective/zk-Harness/frameworks/gnark/parser"
+	"github.com/zkCollective/zk-Harness/frameworks/gnark/util"
+)
+
+// exportCmd represents the export command
+var exportCmd = &cobra.Command{
+	Use:   "export",
+	Short: "exports the compiled constraint system of a circuit and the value of its wires for other frameworks",
+	Run:   runExport,
+}
+
+var (
+	fExportBackend *string
+	fExportFormat  *string
+)
+
+func runExport(cmd *cobra.Command, args []string) {
+	log := logger.Logger()
+	log.Info().Msg("Exporting " + *cfg.Circuit + " - gnark, " + *fExportBackend + ": " + *cfg.Curve + " " + *cfg.InputPath + " as " + *fExportFormat)
+
+	*cfg.Backend = *fExportBackend
+	if err := parser.ParseFlags(cfg); err != nil {
+		fmt.Println("error: ", err.Error())
+		cmd.Help()
+		os.Exit(-1)
+	}
+	if *cfg.OutputPath == "None" {
+		fmt.Println("error: missing output path")
+		cmd.Help()
+		os.Exit(-1)
+	}
+	switch *fExportFormat {
+	case "json":
+	case "r1cs-bin":
+		if *fExportBackend != "groth16" {
+			fmt.Println("error: r1cs-bin exports the R1CS of groth16, the gates of plonk are exported as json")
+			os.Exit(-1)
+		}
+	default:
+		fmt.Println("error: invalid format, must be r1cs-bin or json")
+		cmd.Help()
+		os.Exit(-1)
+	}
+
+	newBuilder := r1cs.NewBuilder
+	if *fExportBackend == "plonk" {
+		newBuilder = scs.NewBuilder
+	}
+	ccs, err := frontend.Compile(
+		parser.CurveID.ScalarField(),
+		newBuilder,
+		parser.C.Circuit(*cfg.CircuitSize, *cfg.Circuit, circuits.WithInputCircuit(*cfg.InputPath)),
+		frontend.WithCapacity(*cfg.CircuitSize),
+		frontend.IgnoreUnconstrainedInputs())
+	assertNoError(err)
+	if util.NbCommitments(ccs) > 0 {
+		fmt.Println("error: the commitments of the circuit are bound to the gnark prover and cannot be exported")
+		os.Exit(-1)
+	}
+
+	witness := parser.C.Witness(*cfg.CircuitSize, parser.CurveID, *cfg.Circuit, circuits.WithInputWitness(*cfg.InputPath))
+	wires, err := util.SolvedWires(ccs, witness)
+	assertNoError(err)
+
+	// the files are named after the output path, e.g. mimc.r1cs and mimc.wtns for mimc.r1cs
+	stem := strings.TrimSuffix(*cfg.OutputPath, filepath.Ext(*cfg.OutputPath))
+	if *fExportFormat == "r1cs-bin" {
+		exportFile(stem+".r1cs", func(w io.Writer) error { return circom.WriteR1CS(w, ccs.(constraint.R1CS)) })
+		exportFile(stem+".wtns", func(w io.Writer) error { return circom.WriteWitness(w, ccs.Field(), wires) })
+		return
+	}
+	exportFile(stem+".json", func(w io.Writer) error { return util.ExportJSON(w, ccs, parser.CurveID) })
+	exportFile(stem+"_witness.json", func(w io.Writer) error { return util.ExportWitnessJSON(w, wires, parser.CurveID) })
+}
+
+// exportFile creates the file at path and writes it with write
+func exportFile(path string, write func(w io.Writer) error) {
+	f, err := os.Create(path)
+	assertNoError(err)
+	assertNoError(write(f))
+	assertNoError(f.Close())
+	fmt.Println("exported " + path)
+}
+
+func init() {
+	fExportBackend = exportCmd.Flags().String("backend", "groth16", "backend whose constraint system is exported, groth16 for the R1CS or plonk for the gates of the sparse R1CS")
+	fExportFormat = exportCmd.Flags().String("format", "r1cs-bin", "format of the export, r1cs-bin for the R1CS and wtns files of iden3 or json")
+
+	rootCmd.AddCommand(exportCmd)
+}
@@ -16,13 +16,16 @@ limitations under the License.
 package cmd
 
 import (
+	"crypto/sha256"
 	"fmt"
+	"io"
 	"os"
 	"runtime"
 	"time"
 
-	"github.com/DmitriyVTitov/size"
+	"github.com/consensys/gnark/backend"
 	"github.com/consensys/gnark/backend/groth16"
+	groth16_bn254 "github.com/consensys/gnark/backend/groth16/bn254"
 	"github.com/consensys/gnark/constraint"
 	"github.com/consensys/gnark/frontend"
 	"github.com/consensys/gnark/frontend/cs/r1cs"
@@ -41,6 +44,11 @@ var groth16Cmd = &cobra.Command{
 	Run:   runGroth16,
 }
 
+var (
+	fKeys          *string
+	groth16Options *util.BackendOptions
+)
+
 func runGroth16(cmd *cobra.Command, args []string) {
 
 	log := logger.Logger()
@@ -48,13 +56,19 @@ func runGroth16(cmd *cobra.Command, args []string) {
 
 	var filename = *cfg.OutputPath
 
+	*cfg.Backend = "groth16"
 	if err := parser.ParseFlags(cfg); err != nil {
 		fmt.Println("error: ", err.Error())
 		cmd.Help()
 		os.Exit(-1)
 	}
+	if err := parseBackendOptions(groth16Options); err != nil {
+		fmt.Println("error: ", err.Error())
+		cmd.Help()
+		os.Exit(-1)
+	}
 
-	writeResults := func(took time.Duration, ccs constraint.ConstraintSystem, proof_size int) {
+	write := func(operation string, took time.Duration, ccs constraint.ConstraintSystem, proof_size int) {
 
 		// check memory usage, max ram requested from OS
 		var m runtime.MemStats
@@ -68,22 +82,56 @@ func runGroth16(cmd *cobra.Command, args []string) {
 			Curve:             parser.CurveID.String(),
 			Circuit:           *cfg.Circuit,
 			Input:             *cfg.InputPath,
-			Operation:         *cfg.Algo,
+			Operation:         operation,
 			NbConstraints:     ccs.GetNbConstraints(),
 			NbSecretVariables: secret,
 			NbPublicVariables: public,
+			NbCommitments:     util.NbCommitments(ccs),
 			ProofSize:         proof_size,
 			MaxRAM:            m.Sys,
 			Count:             *cfg.Count,
 			RunTime:           took.Microseconds(),
+			Options:           groth16Options.String(),
 		}
 
 		if err := util.WriteData("csv", bData, filename); err != nil {
 			panic(err)
 		}
 	}
+
+	writeResults := func(took time.Duration, ccs constraint.ConstraintSystem, proof_size int) {
+		write(*cfg.Algo, took, ccs, proof_size)
+	}
+
+	opts := []util.BenchOption{util.WithInput(*cfg.InputPath)}
+	if !groth16Options.IsDefault() {
+		opts = append(opts, util.WithBackendOptions(*groth16Options, parser.CurveID))
+	}
+	if *cfg.Phases {
+		opts = append(opts, util.WithPhases(func(phase string, took time.Duration, ccs constraint.ConstraintSystem) {
+			write(*cfg.Algo+"_"+phase, took, ccs, 0)
+		}))
+	}
+	if *cfg.MemTimeline > 0 {
+		opts = append(opts, util.WithMemTimeline(*cfg.MemTimeline, writeMemTimeline(filename)))
+	}
+	if *fKeys != "none" {
+		opts = append(opts, util.WithKeys(*fKeys))
+	}
+	if *cfg.Algo == "serialize" {
+		opts = append(opts, util.WithSerialization(serialWriter("groth16", filename)))
+	}
+	if *cfg.Algo == "export-solidity" {
+		opts = append(opts, util.WithSolidity(solidityPath(filename, "groth16"), gasWriter("groth16", filename)))
+	}
 	// Run Benchmarks for Groth16 on given specification
-	benchGroth16(writeResults, *cfg.Algo, *cfg.Count, *cfg.CircuitSize, *cfg.Circuit, util.WithInput(*cfg.InputPath))
+	if len(parser.Threads) > 0 {
+		sweepThreads("groth16", func(fnWrite util.WriteFunction) {
+			benchGroth16(fnWrite, *cfg.Algo, *cfg.Count, *cfg.CircuitSize, *cfg.Circuit, opts...)
+		})
+		return
+	}
+	benchGroth16(writeResults, *cfg.Algo, *cfg.Count, *cfg.CircuitSize, *cfg.Circuit, opts...)
 }
 
 func benchGroth16(fnWrite util.WriteFunction, falgo string, fcount int, fcircuitSize int, fcircuit string, opts ...util.BenchOption) {
@@ -97,16 +145,23 @@ func benchGroth16(fnWrite util.WriteFunction, falgo string, fcount int, fcircuit
 	}
 
 	var (
-		start time.Time
-		took  time.Duration
-		prof  interface{ Stop() }
+		start    time.Time
+		last     time.Time
+		took     time.Duration
+		prof     interface{ Stop() }
+		timeline *util.Timeline
 	)
 
 	startProfile := func() {
 		start = time.Now()
+		last = start
 		if parser.P != nil {
 			prof = profile.Start(parser.P, profile.ProfilePath("."), profile.NoShutdownHook)
 		}
+		if opt.MemTimeline > 0 {
+			// prover phases are marked when their timings are reported
+			timeline = util.StartTimeline(opt.MemTimeline, falgo == "prove" && opt.WritePhase != nil)
+		}
 	}
 
 	stopProfile := func() {
@@ -114,13 +169,24 @@ func benchGroth16(fnWrite util.WriteFunction, falgo string, fcount int, fcircuit
 		if parser.P != nil {
 			prof.Stop()
 		}
+		if timeline != nil {
+			opt.WriteMemory(falgo, timeline.Stop())
+		}
 		took /= time.Duration(fcount)
 	}
 
+	// sample records the time of an iteration of the timed region, stored with the result by --db
+	sample := func() {
+		now := time.Now()
+		util.AddSample(now.Sub(last))
+		last = now
+	}
+
 	circuit := parser.C.Circuit(fcircuitSize,
 		fcircuit,
 		circuits.WithInputCircuit(opt.InputPath),
-		circuits.WithVKCircuit(opt.VerifyingKey))
+		circuits.WithInnerCCSCircuit(opt.CCS),
+		circuits.WithNbProofsCircuit(len(opt.Proofs)))
 
 	if falgo == "compile" {
 		fmt.Println("BENCHMARK CIRCUIT COMPILATION")
@@ -134,6 +200,7 @@ func benchGroth16(fnWrite util.WriteFunction, falgo string, fcount int, fcircuit
 				circuit,
 				frontend.WithCapacity(fcircuitSize),
 				frontend.IgnoreUnconstrainedInputs())
+			sample()
 		}
 		stopProfile()
 		assertNoError(err)
@@ -160,6 +227,7 @@ func benchGroth16(fnWrite util.WriteFunction, falgo string, fcount int, fcircuit
 		var err error
 		for i := 0; i < fcount; i++ {
 			_, _, err = groth16.Setup(ccs)
+			sample()
 		}
 		stopProfile()
 		assertNoError(err)
@@ -179,7 +247,9 @@ func benchGroth16(fnWrite util.WriteFunction, falgo string, fcount int, fcircuit
 				circuits.WithInputWitness(opt.InputPath),
 				circuits.WithVK(opt.VerifyingKey),
 				circuits.WithProof(opt.Proof),
-				circuits.WithWitness(opt.Witness))
+				circuits.WithWitness(opt.Witness),
+				circuits.WithProofs(opt.Proofs, opt.Witnesses))
+			sample()
 		}
 		stopProfile()
 		assertNoError(err)
@@ -198,32 +268,67 @@ func benchGroth16(fnWrite util.WriteFunction, falgo string, fcount int, fcircuit
 		circuits.WithInputWitness(opt.InputPath),
 		circuits.WithVK(opt.VerifyingKey),
 		circuits.WithProof(opt.Proof),
-		circuits.WithWitness(opt.Witness))
+		circuits.WithWitness(opt.Witness),
+		circuits.WithProofs(opt.Proofs, opt.Witnesses))
+
+	if falgo == "serialize" {
+		pk, vk := groth16Keys(ccs, opt.KeysDir)
+		proof, err := groth16.Prove(ccs, pk, witness, opt.ProverOpts...)
+		assertNoError(err)
+		publicWitness, err := witness.Public()
+		assertNoError(err)
+		benchSerialization(opt.WriteSerial, fcount, []serialObject{
+			{"proof", proof, func() io.ReaderFrom { return groth16.NewProof(parser.CurveID) }},
+			{"pk", pk, func() io.ReaderFrom { return groth16.NewProvingKey(parser.CurveID) }},
+			{"vk", vk, func() io.ReaderFrom { return groth16.NewVerifyingKey(parser.CurveID) }},
+			{"ccs", ccs, func() io.ReaderFrom { return groth16.NewCS(parser.CurveID) }},
+			{"witness", witness, newWitness},
+			{"publicWitness", publicWitness, newWitness},
+		})
+		return
+	}
+
+	if falgo == "export-solidity" {
+		pk, vk := groth16Keys(ccs, opt.KeysDir)
+		// the Solidity verifier hashes the commitments to the field with sha256
+		proof, err := groth16.Prove(ccs, pk, witness, backend.WithProverHashToFieldFunction(sha256.New()))
+		assertNoError(err)
+		publicWitness, err := witness.Public()
+		assertNoError(err)
+		calldata := groth16Calldata(proof.(*groth16_bn254.Proof).MarshalSolidity(), publicWitness)
+		benchSolidity(opt.WriteGas, opt.SolidityPath, ccs, vk.(solidityExporter), "Verifier", "verifyProof", calldata)
+		return
+	}
 
 	if falgo == "prove" {
 		fmt.Println("BENCHMARK PROOF GENERATION")
-		pk, _, err := groth16.Setup(ccs)
-		assertNoError(err)
+		pk, _ := groth16Keys(ccs, opt.KeysDir)
 
-		var proof interface{}
+		var proof groth16.Proof
 		startProfile()
 		for i := 0; i < fcount; i++ {
-			proof, err = groth16.Prove(ccs, pk, witness)
+			proof, err = groth16.Prove(ccs, pk, witness, opt.ProverOpts...)
+			sample()
 		}
 		stopProfile()
 		assertNoError(err)
-		proof_size := size.Of(proof)
+		proof_size := util.SerializedSize(proof)
 		fnWrite(took, ccs, proof_size)
+		if opt.WritePhase != nil {
+			writeProvePhases(opt.WritePhase, fcount, ccs, func() error {
+				_, err := groth16.Prove(ccs, pk, witness, opt.ProverOpts...)
+				return err
+			})
+		}
 		return
 	}
 
 	if falgo != "verify" {
 		panic("algo at this stage should be verify")
 	}
-	pk, vk, err := groth16.Setup(ccs)
-	assertNoError(err)
+	pk, vk := groth16Keys(ccs, opt.KeysDir)
 
-	proof, err := groth16.Prove(ccs, pk, witness)
+	proof, err := groth16.Prove(ccs, pk, witness, opt.ProverOpts...)
 	assertNoError(err)
 
 	publicWitness, err := witness.Public()
@@ -231,15 +336,38 @@ func benchGroth16(fnWrite util.WriteFunction, falgo string, fcount int, fcircuit
 	fmt.Println("BENCHMARK PROOF VERIFICATION")
 	startProfile()
 	for i := 0; i < fcount; i++ {
-		err = groth16.Verify(proof, vk, publicWitness)
+		err = groth16.Verify(proof, vk, publicWitness, opt.VerifierOpts...)
+		sample()
 	}
 	stopProfile()
 	assertNoError(err)
 	fnWrite(took, ccs, 0)
 }
 
+// writeProvePhases re-runs the prover to report the time spent in each of its sub-phases
+func writeProvePhases(fnWritePhase util.PhaseWriteFunction, fcount int, ccs constraint.ConstraintSystem, prove func() error) {
+	fmt.Println("BENCHMARK PROOF GENERATION PHASES")
+	phases, err := util.ProvePhases(fcount, prove)
+	if err != nil {
+		fmt.Println("error: phase breakdown failed: ", err.Error())
+		return
+	}
+	for _, phase := range phases {
+		name := phase.Name
+		if phase.Estimated {
+			name += "_estimated"
+		}
+		fnWritePhase(name, phase.Took, ccs)
+	}
+}
+
 func assertNoError(err error) {
 	if err != nil {
 		panic(err)
 	}
 }
+
+func init() {
+	fKeys = groth16Cmd.Flags().String("keys", "none", "directory of the pk.dat and vk.dat to prove and verify with, e.g. written by the ceremony command, instead of the setup")
+	groth16Options = backendFlags(groth16Cmd, "groth16")
+}
@@ -0,0 +1,202 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"time"
+
+	"github.com/consensys/gnark/backend/groth16"
+	"github.com/consensys/gnark/backend/witness"
+	"github.com/consensys/gnark/constraint"
+	"github.com/consensys/gnark/logger"
+	"github.com/pkg/profile"
+	"github.com/spf13/cobra"
+	"github.com/zkCollective/zk-Harness/frameworks/gnark/circuits/circom"
+	"github.com/zkCollective/zk-Harness/frameworks/gnark/parser"
+	"github.com/zkCollective/zk-Harness/frameworks/gnark/util"
+)
+
+// importR1CSCmd represents the import-r1cs command
+var importR1CSCmd = &cobra.Command{
+	Use:   "import-r1cs circuit.r1cs witness.wtns",
+	Short: "benchmarks Groth16 on the constraint system and witness of a circom circuit",
+	Args:  cobra.ExactArgs(2),
+	Run:   runImportR1CS,
+}
+
+func runImportR1CS(cmd *cobra.Command, args []string) {
+	log := logger.Logger()
+	log.Info().Msg("Benchmarking " + args[0] + " - gnark, groth16: " + *cfg.Algo + " " + args[1])
+
+	var filename = *cfg.OutputPath
+
+	if err := parser.ParseFlagsImport(cfg); err != nil {
+		fmt.Println("error: ", err.Error())
+		cmd.Help()
+		os.Exit(-1)
+	}
+
+	// the circuit is named after the R1CS file, e.g. circuit for circuit.r1cs
+	circuit := strings.TrimSuffix(filepath.Base(args[0]), filepath.Ext(args[0]))
+	writeResults := func(took time.Duration, ccs constraint.ConstraintSystem, proof_size int) {
+
+		// check memory usage, max ram requested from OS
+		var m runtime.MemStats
+		runtime.ReadMemStats(&m)
+
+		_, secret, public := ccs.GetNbVariables()
+		bData := util.BenchDataCircuit{
+			Framework:         "gnark",
+			Category:          "circuit",
+			Backend:           "groth16",
+			Curve:             parser.CurveID.String(),
+			Circuit:           circuit,
+			Input:             args[1],
+			Operation:         *cfg.Algo,
+			NbConstraints:     ccs.GetNbConstraints(),
+			NbSecretVariables: secret,
+			NbPublicVariables: public,
+			ProofSize:         proof_size,
+			MaxRAM:            m.Sys,
+			Count:             *cfg.Count,
+			RunTime:           took.Microseconds(),
+		}
+
+		if err := util.WriteData("csv", bData, filename); err != nil {
+			panic(err)
+		}
+	}
+
+	benchImportR1CS(writeResults, *cfg.Algo, *cfg.Count, args[0], args[1])
+}
+
+// benchImportR1CS benchmarks Groth16 on the R1CS file, compile being the time to read it
+// and witness the time to read the wtns file
+func benchImportR1CS(fnWrite util.WriteFunction, falgo string, fcount int, r1csPath string, wtnsPath string) {
+	fmt.Println("BENCHMARKING GROTH16 ON " + r1csPath)
+
+	var (
+		start time.Time
+		took  time.Duration
+		prof  interface{ Stop() }
+	)
+
+	startProfile := func() {
+		start = time.Now()
+		if parser.P != nil {
+			prof = profile.Start(parser.P, profile.ProfilePath("."), profile.NoShutdownHook)
+		}
+	}
+
+	stopProfile := func() {
+		took = time.Since(start)
+		if parser.P != nil {
+			prof.Stop()
+		}
+		took /= time.Duration(fcount)
+	}
+
+	readR1CS := func() (constraint.ConstraintSystem, error) {
+		f, err := os.Open(r1csPath)
+		if err != nil {
+			return nil, err
+		}
+		defer f.Close()
+		return circom.ReadR1CS(f)
+	}
+	readWitness := func(ccs constraint.ConstraintSystem) (witness.Witness, error) {
+		f, err := os.Open(wtnsPath)
+		if err != nil {
+			return nil, err
+		}
+		defer f.Close()
+		return circom.ReadWitness(f, ccs)
+	}
+
+	var (
+		ccs constraint.ConstraintSystem
+		err error
+	)
+	if falgo == "compile" {
+		fmt.Println("BENCHMARK R1CS IMPORT")
+		startProfile()
+		for i := 0; i < fcount; i++ {
+			ccs, err = readR1CS()
+		}
+		stopProfile()
+	} else {
+		ccs, err = readR1CS()
+	}
+	assertNoError(err)
+	parser.CurveID, err = circom.CurveOf(ccs.Field())
+	assertNoError(err)
+	if falgo == "compile" {
+		fnWrite(took, ccs, 0)
+		return
+	}
+
+	if falgo == "setup" {
+		fmt.Println("BENCHMARK SETUP")
+		startProfile()
+		for i := 0; i < fcount; i++ {
+			_, _, err = groth16.Setup(ccs)
+		}
+		stopProfile()
+		assertNoError(err)
+		fnWrite(took, ccs, 0)
+		return
+	}
+
+	if falgo == "witness" {
+		fmt.Println("BENCHMARK WITNESS IMPORT")
+		startProfile()
+		for i := 0; i < fcount; i++ {
+			_, err = readWitness(ccs)
+		}
+		stopProfile()
+		assertNoError(err)
+		fnWrite(took, ccs, 0)
+		return
+	}
+
+	witness, err := readWitness(ccs)
+	assertNoError(err)
+	pk, vk, err := groth16.Setup(ccs)
+	assertNoError(err)
+
+	if falgo == "prove" {
+		fmt.Println("BENCHMARK PROOF GENERATION")
+		var proof groth16.Proof
+		startProfile()
+		for i := 0; i < fcount; i++ {
+			proof, err = groth16.Prove(ccs, pk, witness)
+		}
+		stopProfile()
+		assertNoError(err)
+		fnWrite(took, ccs, util.SerializedSize(proof))
+		return
+	}
+
+	if falgo != "verify" {
+		panic("algo at this stage should be verify")
+	}
+	proof, err := groth16.Prove(ccs, pk, witness)
+	assertNoError(err)
+	publicWitness, err := witness.Public()
+	assertNoError(err)
+	fmt.Println("BENCHMARK PROOF VERIFICATION")
+	startProfile()
+	for i := 0; i < fcount; i++ {
+		err = groth16.Verify(proof, vk, publicWitness)
+	}
+	stopProfile()
+	assertNoError(err)
+	fnWrite(took, ccs, 0)
+}
+
+func init() {
+	rootCmd.AddCommand(importR1CSCmd)
+}
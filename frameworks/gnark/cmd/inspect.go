@@ -0,0 +1,130 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/consensys/gnark/constraint"
+	"github.com/consensys/gnark/frontend"
+	"github.com/consensys/gnark/frontend/cs/r1cs"
+	"github.com/consensys/gnark/frontend/cs/scs"
+	"github.com/consensys/gnark/logger"
+	gnarkprofile "github.com/consensys/gnark/profile"
+	"github.com/spf13/cobra"
+	"github.com/zkCollective/zk-Harness/frameworks/gnark/circuits"
+	"github.com/zkCollective/zk-Harness/frameworks/gnark/parser"
+	"github.com/zkCollective/zk-Harness/frameworks/gnark/util"
+)
+
+// inspectCmd represents the inspect command
+var inspectCmd = &cobra.Command{
+	Use:   "inspect",
+	Short: "compiles a circuit and reports its constraint system statistics and constraint profile",
+	Run:   runInspect,
+}
+
+var (
+	fInspectBackend *string
+	fPprofPath      *string
+)
+
+func runInspect(cmd *cobra.Command, args []string) {
+	log := logger.Logger()
+	log.Info().Msg("Inspecting " + *cfg.Circuit + " - gnark, " + *fInspectBackend + ": " + *cfg.Curve + " " + *cfg.InputPath)
+
+	*cfg.Backend = *fInspectBackend
+	if err := parser.ParseFlags(cfg); err != nil {
+		fmt.Println("error: ", err.Error())
+		cmd.Help()
+		os.Exit(-1)
+	}
+
+	var newBuilder frontend.NewBuilder
+	switch *fInspectBackend {
+	case "groth16":
+		newBuilder = r1cs.NewBuilder
+	case "plonk":
+		newBuilder = scs.NewBuilder
+	default:
+		fmt.Println("error: invalid backend, must be groth16 or plonk")
+		cmd.Help()
+		os.Exit(-1)
+	}
+
+	circuit := parser.C.Circuit(*cfg.CircuitSize,
+		*cfg.Circuit,
+		circuits.WithInputCircuit(*cfg.InputPath))
+
+	// attribute every constraint to the source line that created it
+	p := gnarkprofile.Start(gnarkprofile.WithPath(*fPprofPath))
+	ccs, err := frontend.Compile(
+		parser.CurveID.ScalarField(),
+		newBuilder,
+		circuit,
+		frontend.WithCapacity(*cfg.CircuitSize),
+		frontend.IgnoreUnconstrainedInputs())
+	p.Stop()
+	assertNoError(err)
+
+	stats, err := util.InspectConstraintSystem(ccs)
+	assertNoError(err)
+
+	printStats(stats, ccs)
+	fmt.Println("CONSTRAINTS PER SOURCE LINE (written to " + *fPprofPath + ")")
+	fmt.Println(p.Top())
+
+	bData := util.BenchDataStats{
+		Framework:           "gnark",
+		Category:            "inspect",
+		Backend:             *fInspectBackend,
+		Curve:               parser.CurveID.String(),
+		Circuit:             *cfg.Circuit,
+		Input:               *cfg.InputPath,
+		NbConstraints:       stats.NbConstraints,
+		NbInternalVariables: stats.NbInternalVariables,
+		NbSecretVariables:   stats.NbSecretVariables,
+		NbPublicVariables:   stats.NbPublicVariables,
+		NbCoefficients:      stats.NbCoefficients,
+		NbCommitments:       stats.NbCommitments,
+		NbHintCalls:         stats.NbHintCalls,
+		Hints:               stats.HintCalls(),
+		NbLevels:            stats.NbLevels,
+		MaxLevelWidth:       stats.MaxLevelWidth,
+		AvgLevelWidth:       stats.AvgLevelWidth,
+	}
+
+	if *cfg.OutputPath == "None" {
+		err = util.WriteData("csv", bData)
+	} else {
+		err = util.WriteData("csv", bData, *cfg.OutputPath)
+	}
+	if err != nil {
+		panic(err)
+	}
+}
+
+// printStats prints a human readable summary of the constraint system statistics
+func printStats(stats util.CircuitStats, ccs constraint.ConstraintSystem) {
+	fmt.Println("CONSTRAINT SYSTEM STATISTICS")
+	fmt.Printf("%-24s %d\n", "constraints", stats.NbConstraints)
+	fmt.Printf("%-24s %d\n", "instructions", ccs.GetNbInstructions())
+	fmt.Printf("%-24s %d\n", "internal variables", stats.NbInternalVariables)
+	fmt.Printf("%-24s %d\n", "secret variables", stats.NbSecretVariables)
+	fmt.Printf("%-24s %d\n", "public variables", stats.NbPublicVariables)
+	fmt.Printf("%-24s %d\n", "coefficients", stats.NbCoefficients)
+	fmt.Printf("%-24s %d\n", "commitments", stats.NbCommitments)
+	fmt.Printf("%-24s %d\n", "hint calls", stats.NbHintCalls)
+	for _, h := range stats.Hints {
+		fmt.Printf("  %-22s %d\n", h.Name, h.Calls)
+	}
+	fmt.Printf("%-24s %d\n", "solver levels", stats.NbLevels)
+	fmt.Printf("%-24s %d\n", "max level width", stats.MaxLevelWidth)
+	fmt.Printf("%-24s %.2f\n", "avg level width", stats.AvgLevelWidth)
+}
+
+func init() {
+	fInspectBackend = inspectCmd.Flags().String("backend", "groth16", "backend the circuit is compiled for. must be groth16 or plonk")
+	fPprofPath = inspectCmd.Flags().String("pprofPath", "gnark.pprof", "output path of the pprof constraint profile")
+
+	rootCmd.AddCommand(inspectCmd)
+}
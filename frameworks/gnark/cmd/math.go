@@ -4,16 +4,17 @@ Benchmarking Math Operations over a variety of curves in gnark
 package cmd
 
 import (
-	"bufio"
 	"fmt"
 	"os"
-	"os/exec"
 	"runtime"
+	"slices"
 	"strconv"
 	"strings"
 
+	"github.com/consensys/gnark-crypto/ecc"
 	"github.com/consensys/gnark/logger"
 	"github.com/spf13/cobra"
+	"github.com/zkCollective/zk-Harness/frameworks/gnark/ec"
 	"github.com/zkCollective/zk-Harness/frameworks/gnark/parser"
 	"github.com/zkCollective/zk-Harness/frameworks/gnark/util"
 )
@@ -22,126 +23,99 @@ const (
 	pathPrefix = "../benchmarks/gnark/math"
 )
 
+var fSizes *string
+
 var mathCmd = &cobra.Command{
 	Use:   "ec",
 	Short: "runs benchmarks and profiles for the gnark arithmetic operations",
 	Run:   benchCurveOperations,
 }
 
-// Result struct to hold values from txt files
-type Result struct {
-	Operation string
-	Runtime   float64
-	Count     int64
-}
-
-// benchCurveOperations runs benchmarks for a variety of curves in gnark
+// benchCurveOperations runs the field, group, FFT and pairing benchmarks of gnark-crypto
+// for the selected curves, or every implemented curve with --curve=all
 func benchCurveOperations(cmd *cobra.Command, args []string) {
-
 	log := logger.Logger()
-	log.Info().Msg("Benchmarking curve operations - gnark: " + *fCurve + " " + *fOperation + " " + *fInputPath)
-
-	var filepath_zkalc = pathPrefix + "/zkalc/gnark_" +
-		"curve_" +
-		*fCurve +
-		"." + "txt"
-
-	var filepath_zkHarness = pathPrefix + "/zkHarness/gnark_" +
-		"curve_" +
-		*fCurve +
-		"." + "txt"
-
-	paths := []string{
-		pathPrefix,
-		pathPrefix + "/zkalc",
-		pathPrefix + "/zkHarness",
-	}
+	log.Info().Msg("Benchmarking curve operations - gnark: " + *cfg.Curve + " " + *fGroup + " " + *cfg.Operation)
 
-	for _, path := range paths {
-		err := os.MkdirAll(path, 0755)
-		if err != nil {
-			log.Printf("Failed to create directory '%s': %s\n", path, err)
-		} else {
-			log.Printf("Directory '%s' created successfully\n", path)
-		}
-	}
-
-	if err := parser.ParseFlags(cfg); err != nil {
+	if err := parser.ParseFlagsEC(cfg); err != nil {
 		fmt.Println("error: ", err.Error())
 		cmd.Help()
 		os.Exit(-1)
 	}
 
-	// Clean up for new benchmarks
-	if err := cleanup(); err != nil {
-		log.Printf("Cleanup failed: %s\n", err)
+	var sizes []int
+	if *fSizes != "none" {
+		var err error
+		if sizes, err = ec.ParseSizes(*fSizes); err != nil {
+			fmt.Println("error: ", err.Error())
+			cmd.Help()
+			os.Exit(-1)
+		}
 	}
 
-	// Clone gnark-crypto repo
-	cmdShell := exec.Command("git", "clone", "-b", "zkalc", "https://github.com/ConsenSys/gnark-crypto.git")
-	cmdShell.Dir = ".."
-	err := cmdShell.Run()
-	if err != nil {
-		log.Printf("git clone failed with %s\n", err)
+	switch *fGroup {
+	case "None", "fr", "fp", "g1", "g2", "gt":
+	default:
+		fmt.Println("error: invalid group, must be None, fr, fp, g1, g2 or gt")
+		cmd.Help()
+		os.Exit(-1)
 	}
 
-	// Benchmark for all curves in config
-	if err := bench_math(parser.CurveID.String(), filepath_zkalc); err != nil {
-		log.Printf("Benchmark failed: %s\n", err)
+	if operations := ec.Operations(*fGroup); *cfg.Operation != "None" && !slices.Contains(operations, *cfg.Operation) {
+		fmt.Println("error: invalid operation " + *cfg.Operation + " of group " + *fGroup + ", must be None or one of " + strings.Join(operations, ", "))
+		cmd.Help()
+		os.Exit(-1)
 	}
 
-	results, err := readBenchmarkFile(filepath_zkalc)
-	if err != nil {
-		log.Printf("Reading benchmark file failed: %s\n", err)
-		return
-	}
-	for _, result := range results {
-		if err := writeResults(result, filepath_zkHarness); err != nil {
-			log.Printf("Writing results failed: %s\n", err)
-			return
+	// the machine is described once for all the records of the run
+	machine := util.DescribeMachine()
+	for _, curve := range parser.Curves {
+		if _, ok := ec.BenchCurves[curve]; !ok {
+			log.Warn().Msg("Skipping " + curve.String() + ", curve operations not implemented")
+			continue
 		}
-	}
 
-	if err := cleanup(); err != nil {
-		log.Printf("Cleanup failed: %s\n", err)
-	}
-}
+		filename := *cfg.OutputPath
+		if filename == "None" {
+			filename = pathPrefix + "/zkHarness/gnark_curve_" + strings.ToLower(curve.String()) + ".txt"
+			assertNoError(os.MkdirAll(pathPrefix+"/zkHarness", 0755))
+			// the default output of a curve only holds the latest run
+			if err := os.Remove(filename); err != nil && !os.IsNotExist(err) {
+				panic(err)
+			}
+		}
 
-// bench_math runs a shell command to perform a math benchmark with a specified curve
-func bench_math(curve string, filename string) error {
-	curve = strings.Replace(curve, "_", "-", -1)
-	command := exec.Command("bash", "-c", "bash ./zkalc.sh "+curve+" | grep -vE '^[^[:space:]]+/' | tee "+filename)
-	command.Dir = "../gnark-crypto"
-	err := command.Run()
-	if err != nil {
-		return fmt.Errorf("Command failed with error: %s", err)
+		err := ec.Run(curve, *fGroup, *cfg.Operation, sizes, func(result ec.Result) error {
+			return writeResults(curve, result, machine, filename)
+		})
+		assertNoError(err)
 	}
-	return nil
 }
 
-// writeResults writes the results of a benchmark to a file
-func writeResults(result Result, filename string) error {
+// writeResults writes the results of a benchmark on the machine to a file
+func writeResults(curve ecc.ID, result ec.Result, machine util.Machine, filename string) error {
 	// check memory usage, max ram requested from OS
 	var m runtime.MemStats
 	runtime.ReadMemStats(&m)
 
+	input := ""
+	if result.Size > 0 {
+		input = strconv.Itoa(result.Size)
+	}
+
 	bDataArith := util.BenchDataCurve{
 		Framework: "gnark",
 		Category:  "ec",
-		Curve:     parser.CurveID.String(),
-		Operation: result.Operation,
-		Input:     "", // This needs to be replaced with the appropriate value
+		Curve:     curve.String(),
+		Operation: result.Group + "-" + result.Operation,
+		Input:     input,
 		MaxRAM:    m.Sys,
-		Count:     int(result.Count),
-		RunTime:   int64(result.Runtime),
-	}
+		Count:     result.N,
+		RunTime:   result.NsPerOp,
+		Machine:   machine,
 
-	// Check if file exists
-	if _, err := os.Stat(filename); !os.IsNotExist(err) {
-		// If file exists, remove it
-		if err := os.Remove(filename); err != nil {
-			return fmt.Errorf("failed to remove existing file: %s", err)
-		}
+		Throughput: result.Throughput,
+		NLogNCost:  result.NLogNCost,
 	}
 
 	if err := util.WriteData("csv", bDataArith, filename); err != nil {
@@ -150,53 +124,9 @@ func writeResults(result Result, filename string) error {
 	return nil
 }
 
-// readBenchmarkFile reads a file of benchmarks and returns the results
-func readBenchmarkFile(filepath string) ([]Result, error) {
-	file, err := os.Open(filepath)
-	if err != nil {
-		return nil, fmt.Errorf("failed to open file: %s", err)
-	}
-	defer file.Close()
-
-	var results []Result
-	scanner := bufio.NewScanner(file)
-	for scanner.Scan() {
-		line := scanner.Text()
-		if strings.HasPrefix(line, "Benchmark") {
-			splitLine := strings.Fields(line)
-			if len(splitLine) < 3 {
-				fmt.Printf("Invalid line format: %s\n", line)
-				continue
-			}
-			operation := splitLine[0]
-			count, _ := strconv.ParseInt(splitLine[1], 10, 64)
-			runtimeStr := strings.TrimSuffix(splitLine[2], "ns/op")
-			runtime, _ := strconv.ParseFloat(runtimeStr, 64)
-			results = append(results, Result{
-				Operation: operation,
-				Runtime:   runtime,
-				Count:     count,
-			})
-		}
-	}
-
-	if err := scanner.Err(); err != nil {
-		return nil, fmt.Errorf("failed to scan file: %s", err)
-	}
-	return results, nil
-}
-
-// cleanup removes the gnark-crypto directory
-func cleanup() error {
-	err := os.RemoveAll("../gnark-crypto")
-	if err != nil {
-		return fmt.Errorf("Failed to delete the cloned repository: %s", err)
-	}
-	return nil
-}
-
 func init() {
-	fGroup = mathCmd.Flags().String("group", "None", "group to benchmark")
+	fGroup = mathCmd.Flags().String("group", "None", "group to benchmark. must be None, fr, fp, g1, g2 or gt")
+	fSizes = mathCmd.Flags().String("sizes", "none", "sizes of the msm and fft sweeps, e.g. 2^10..2^24 or 1024,4096")
 
 	rootCmd.AddCommand(mathCmd)
 }
@@ -0,0 +1,135 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"slices"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/spf13/cobra"
+	"github.com/zkCollective/zk-Harness/frameworks/gnark/parser"
+)
+
+// matrixCmd represents the matrix command
+var matrixCmd = &cobra.Command{
+	Use:   "matrix",
+	Short: "prints which circuits the groth16 and plonk commands benchmark over which curves for --algo",
+	Run:   runMatrix,
+}
+
+func runMatrix(cmd *cobra.Command, args []string) {
+	if err := parser.ParseFlagsMatrix(cfg); err != nil {
+		fmt.Println("error: ", err.Error())
+		cmd.Help()
+		os.Exit(-1)
+	}
+
+	title, cells := *cfg.Algo, parser.Matrix(*cfg.Algo)
+	if *fRecursion {
+		title, cells = "recursion", parser.RecursionMatrix()
+	}
+
+	// the columns of the curves, in the order of the cells of a row
+	var curves []string
+	for _, c := range cells {
+		if slices.Contains(curves, c.Column()) {
+			break
+		}
+		curves = append(curves, c.Column())
+	}
+
+	// the circuits and curves without any supported cell are left out of the table with a reason
+	rowReason := make(map[string]error)
+	curveReason := make(map[string]error)
+	for _, c := range cells {
+		key := c.Circuit + " " + c.Backend
+		if _, ok := rowReason[key]; !ok {
+			rowReason[key] = c.Err
+		}
+		if _, ok := curveReason[c.Column()]; !ok {
+			curveReason[c.Column()] = c.Err
+		}
+		if c.Err == nil {
+			rowReason[key], curveReason[c.Column()] = nil, nil
+		}
+	}
+	var columns []string
+	for _, column := range curves {
+		if curveReason[column] == nil {
+			columns = append(columns, column)
+		}
+	}
+
+	// the reasons of the cells are numbered in the order they first appear in the table
+	var reasons []string
+	reasonOf := func(err error) string {
+		for i, r := range reasons {
+			if r == err.Error() {
+				return fmt.Sprintf("(%d)", i+1)
+			}
+		}
+		reasons = append(reasons, err.Error())
+		return fmt.Sprintf("(%d)", len(reasons))
+	}
+
+	fmt.Println("SUPPORT MATRIX: " + title)
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	header := append([]string{"circuit", "backend"}, columns...)
+	fmt.Fprintln(w, strings.Join(header, "\t"))
+	var leftOut []string
+	for i := 0; i < len(cells); i += len(curves) {
+		if err := rowReason[cells[i].Circuit+" "+cells[i].Backend]; err != nil {
+			leftOut = appendReason(leftOut, err)
+			continue
+		}
+		row := []string{cells[i].Circuit, cells[i].Backend}
+		for _, c := range cells[i : i+len(curves)] {
+			if curveReason[c.Column()] != nil {
+				continue
+			}
+			if c.Err == nil {
+				row = append(row, "yes")
+			} else {
+				row = append(row, reasonOf(c.Err))
+			}
+		}
+		fmt.Fprintln(w, strings.Join(row, "\t"))
+	}
+	w.Flush()
+
+	fmt.Println()
+	for i, r := range reasons {
+		fmt.Printf("(%d) %s\n", i+1, r)
+	}
+	for _, column := range curves {
+		if err := curveReason[column]; err != nil {
+			leftOut = appendReason(leftOut, err)
+		}
+	}
+	if len(leftOut) > 0 {
+		fmt.Println("LEFT OUT")
+		for _, l := range leftOut {
+			fmt.Println(l)
+		}
+	}
+}
+
+// appendReason appends the reason to reasons unless it is listed already
+func appendReason(reasons []string, err error) []string {
+	if slices.Contains(reasons, err.Error()) {
+		return reasons
+	}
+	return append(reasons, err.Error())
+}
+
+var fRecursion *bool
+
+func init() {
+	fRecursion = matrixCmd.Flags().Bool("recursion", false, "print the matrix of the recursion and aggregate commands, by inner backend and pair of inner and outer curves, instead of --algo")
+	// the matrix reads no input, the local flag standing in for the required one of the root command
+	matrixCmd.Flags().String("input", "none", "unused")
+	matrixCmd.Flags().MarkHidden("input")
+
+	rootCmd.AddCommand(matrixCmd)
+}
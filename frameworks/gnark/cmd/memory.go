@@ -0,0 +1,289 @@
+package cmd
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strconv"
+
+	"github.com/consensys/gnark/backend/groth16"
+	"github.com/consensys/gnark/backend/plonk"
+	"github.com/consensys/gnark/backend/witness"
+	"github.com/consensys/gnark/constraint"
+	"github.com/consensys/gnark/frontend"
+	"github.com/consensys/gnark/frontend/cs/r1cs"
+	"github.com/consensys/gnark/frontend/cs/scs"
+	"github.com/consensys/gnark/logger"
+	"github.com/spf13/cobra"
+	"github.com/zkCollective/zk-Harness/frameworks/gnark/circuits"
+	"github.com/zkCollective/zk-Harness/frameworks/gnark/parser"
+	"github.com/zkCollective/zk-Harness/frameworks/gnark/util"
+)
+
+// memoryCmd represents the memory command
+var memoryCmd = &cobra.Command{
+	Use:   "memory",
+	Short: "runs each phase in a fresh child process and reports its peak resident set size",
+	Run:   runMemory,
+}
+
+var (
+	fMemoryBackend *string
+	fPhase         *string
+	fArtifactsDir  *string
+	fMemoryChild   *bool
+)
+
+// memoryPhases are the phases in the order in which they produce and consume the artifacts
+var memoryPhases = []string{"compile", "setup", "witness", "prove", "verify"}
+
+func runMemory(cmd *cobra.Command, args []string) {
+	*cfg.Backend = *fMemoryBackend
+	if err := parser.ParseFlagsMemory(cfg); err != nil {
+		fmt.Println("error: ", err.Error())
+		cmd.Help()
+		os.Exit(-1)
+	}
+
+	// the child runs a single phase, its peak memory being measured by the parent
+	if *fMemoryChild {
+		if err := runMemoryPhase(*fMemoryBackend, *fPhase, *fArtifactsDir); err != nil {
+			fmt.Println("error: ", err.Error())
+			os.Exit(1)
+		}
+		return
+	}
+
+	phases := []string{*fPhase}
+	if *fPhase == "all" {
+		phases = memoryPhases
+	} else if !isMemoryPhase(*fPhase) {
+		fmt.Println("error: invalid phase, must be compile, setup, witness, prove, verify or all")
+		cmd.Help()
+		os.Exit(-1)
+	}
+
+	if err := os.MkdirAll(*fArtifactsDir, 0755); err != nil {
+		fmt.Println("error: ", err.Error())
+		os.Exit(-1)
+	}
+
+	executable, err := os.Executable()
+	assertNoError(err)
+
+	log := logger.Logger()
+	for _, phase := range phases {
+		log.Info().Msg("Benchmarking memory of " + *cfg.Circuit + " - gnark, " + *fMemoryBackend + ": " + phase + " " + *cfg.Curve + " " + *cfg.InputPath)
+
+		maxRSS, took, err := util.RunChild(executable,
+			"memory",
+			"--child",
+			"--backend="+*fMemoryBackend,
+			"--phase="+phase,
+			"--artifacts-dir="+*fArtifactsDir,
+			"--circuit="+*cfg.Circuit,
+			"--size="+strconv.Itoa(*cfg.CircuitSize),
+			"--curve="+*cfg.Curve,
+			"--input="+*cfg.InputPath,
+			"--srs="+*cfg.SRS)
+		if err != nil {
+			fmt.Println("error: phase " + phase + " failed: " + err.Error())
+			os.Exit(1)
+		}
+
+		bData := util.BenchDataMemory{
+			Framework: "gnark",
+			Category:  "memory",
+			Backend:   *fMemoryBackend,
+			Curve:     parser.CurveID.String(),
+			Circuit:   *cfg.Circuit,
+			Input:     *cfg.InputPath,
+			Operation: phase,
+			MaxRSS:    maxRSS,
+			RunTime:   took.Milliseconds(),
+		}
+		if err := util.WriteData("csv", bData, *cfg.OutputPath); err != nil {
+			panic(err)
+		}
+	}
+}
+
+func isMemoryPhase(phase string) bool {
+	for _, p := range memoryPhases {
+		if p == phase {
+			return true
+		}
+	}
+	return false
+}
+
+// runMemoryPhase runs the phase, reading the artifacts of the previous phases from and writing its own to dir
+func runMemoryPhase(backend string, phase string, dir string) error {
+	switch phase {
+	case "compile":
+		newBuilder := r1cs.NewBuilder
+		if backend == "plonk" {
+			newBuilder = scs.NewBuilder
+		}
+		ccs, err := frontend.Compile(parser.CurveID.ScalarField(),
+			newBuilder,
+			parser.C.Circuit(
+				*cfg.CircuitSize,
+				*cfg.Circuit,
+				circuits.WithInputCircuit(*cfg.InputPath)),
+			frontend.WithCapacity(*cfg.CircuitSize))
+		if err != nil {
+			return err
+		}
+		return writeArtifact(dir, "ccs.dat", ccs)
+
+	case "setup":
+		ccs := newMemoryCS(backend)
+		if err := readArtifact(dir, "ccs.dat", ccs); err != nil {
+			return err
+		}
+		var pk, vk io.WriterTo
+		if backend == "plonk" {
+			srs, srsLagrange := newPlonkSRS(ccs, *cfg.SRS, nil)
+			var err error
+			if pk, vk, err = plonk.Setup(ccs, srs, srsLagrange); err != nil {
+				return err
+			}
+		} else {
+			var err error
+			if pk, vk, err = groth16.Setup(ccs); err != nil {
+				return err
+			}
+		}
+		if err := writeArtifact(dir, "pk.dat", pk); err != nil {
+			return err
+		}
+		return writeArtifact(dir, "vk.dat", vk)
+
+	case "witness":
+		w := parser.C.Witness(*cfg.CircuitSize,
+			parser.CurveID,
+			*cfg.Circuit,
+			circuits.WithInputWitness(*cfg.InputPath))
+		return writeArtifact(dir, "witness.dat", w)
+
+	case "prove":
+		ccs := newMemoryCS(backend)
+		if err := readArtifact(dir, "ccs.dat", ccs); err != nil {
+			return err
+		}
+
+		// Witness creation is included in Prover Memory benchmarks
+		w := parser.C.Witness(*cfg.CircuitSize,
+			parser.CurveID,
+			*cfg.Circuit,
+			circuits.WithInputWitness(*cfg.InputPath))
+
+		var proof io.WriterTo
+		if backend == "plonk" {
+			pk := plonk.NewProvingKey(parser.CurveID)
+			if err := readArtifact(dir, "pk.dat", pk); err != nil {
+				return err
+			}
+			var err error
+			if proof, err = plonk.Prove(ccs, pk, w); err != nil {
+				return err
+			}
+		} else {
+			pk := groth16.NewProvingKey(parser.CurveID)
+			if err := readArtifact(dir, "pk.dat", pk); err != nil {
+				return err
+			}
+			var err error
+			if proof, err = groth16.Prove(ccs, pk, w); err != nil {
+				return err
+			}
+		}
+
+		publicWitness, err := w.Public()
+		if err != nil {
+			return err
+		}
+		if err := writeArtifact(dir, "publicWitness.dat", publicWitness); err != nil {
+			return err
+		}
+		return writeArtifact(dir, "proof.dat", proof)
+
+	case "verify":
+		publicWitness, err := witness.New(parser.CurveID.ScalarField())
+		if err != nil {
+			return err
+		}
+		if err := readArtifact(dir, "publicWitness.dat", publicWitness); err != nil {
+			return err
+		}
+
+		if backend == "plonk" {
+			vk, proof := plonk.NewVerifyingKey(parser.CurveID), plonk.NewProof(parser.CurveID)
+			if err := readArtifact(dir, "vk.dat", vk); err != nil {
+				return err
+			}
+			if err := readArtifact(dir, "proof.dat", proof); err != nil {
+				return err
+			}
+			return plonk.Verify(proof, vk, publicWitness)
+		}
+		vk, proof := groth16.NewVerifyingKey(parser.CurveID), groth16.NewProof(parser.CurveID)
+		if err := readArtifact(dir, "vk.dat", vk); err != nil {
+			return err
+		}
+		if err := readArtifact(dir, "proof.dat", proof); err != nil {
+			return err
+		}
+		return groth16.Verify(proof, vk, publicWitness)
+
+	default:
+		return errors.New("invalid phase " + phase)
+	}
+}
+
+// newMemoryCS allocates the constraint system of the backend to deserialize into
+func newMemoryCS(backend string) constraint.ConstraintSystem {
+	if backend == "plonk" {
+		return plonk.NewCS(parser.CurveID)
+	}
+	return groth16.NewCS(parser.CurveID)
+}
+
+// writeArtifact serializes the artifact to the file name in dir
+func writeArtifact(dir string, name string, artifact io.WriterTo) error {
+	f, err := os.Create(filepath.Join(dir, name))
+	if err != nil {
+		return err
+	}
+	if _, err := artifact.WriteTo(f); err != nil {
+		f.Close()
+		return fmt.Errorf("write %s: %w", name, err)
+	}
+	return f.Close()
+}
+
+// readArtifact deserializes the artifact from the file name in dir
+func readArtifact(dir string, name string, artifact io.ReaderFrom) error {
+	f, err := os.Open(filepath.Join(dir, name))
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+	if _, err := artifact.ReadFrom(f); err != nil {
+		return fmt.Errorf("read %s: %w", name, err)
+	}
+	return nil
+}
+
+func init() {
+	fMemoryBackend = memoryCmd.Flags().String("backend", "groth16", "backend to profile. must be groth16 or plonk")
+	fPhase = memoryCmd.Flags().String("phase", "all", "phase to profile. must be compile, setup, witness, prove, verify or all")
+	fArtifactsDir = memoryCmd.Flags().String("artifacts-dir", "tmp", "directory the artifacts of the phases are serialized to")
+	fMemoryChild = memoryCmd.Flags().Bool("child", false, "run the phase in this process, set for the child processes")
+	memoryCmd.Flags().MarkHidden("child")
+
+	rootCmd.AddCommand(memoryCmd)
+}
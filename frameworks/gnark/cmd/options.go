@@ -0,0 +1,36 @@
+package cmd
+
+import (
+	"errors"
+
+	"github.com/spf13/cobra"
+	"github.com/zkCollective/zk-Harness/frameworks/gnark/parser"
+	"github.com/zkCollective/zk-Harness/frameworks/gnark/util"
+)
+
+// backendFlags registers the flags of the prover and verifier options on the command of the backend,
+// the Fiat-Shamir hashes being those of PlonK
+func backendFlags(cmd *cobra.Command, backend string) *util.BackendOptions {
+	options := &util.BackendOptions{}
+	hashes := "default, sha256, keccak256 or mimc"
+	cmd.Flags().StringVar(&options.HashToField, "hash-to-field", "default", "hash of the commitments to the field. must be "+hashes)
+	if backend == "plonk" {
+		cmd.Flags().StringVar(&options.ChallengeHash, "challenge-hash", "default", "hash of the Fiat-Shamir challenges. must be "+hashes)
+		cmd.Flags().StringVar(&options.KZGFoldingHash, "kzg-folding-hash", "default", "hash of the challenge folding the KZG openings. must be "+hashes)
+	}
+	// custom hints cannot be set: the circuits only call the hints of gnark/std, registered on import,
+	// such that solver.WithHints would add none of them
+	cmd.Flags().IntVar(&options.SolverTasks, "solver-tasks", 0, "number of tasks the solver splits the constraints of a level into, the default of gnark if 0. the only solver option supported, the registered hints of gnark being used as is")
+	return options
+}
+
+// parseBackendOptions checks the options of the backend over the curve of the flags
+func parseBackendOptions(options *util.BackendOptions) error {
+	if err := options.Validate(parser.CurveID); err != nil {
+		return err
+	}
+	if *cfg.Algo == "export-solidity" && !options.IsDefault() {
+		return errors.New("the Solidity verifier only verifies proofs with the default options")
+	}
+	return nil
+}
@@ -17,17 +17,17 @@ package cmd
 
 import (
 	"fmt"
+	"io"
 	"os"
 	"runtime"
 	"time"
 
-	"github.com/DmitriyVTitov/size"
 	"github.com/consensys/gnark/backend/plonk"
+	plonk_bn254 "github.com/consensys/gnark/backend/plonk/bn254"
 	"github.com/consensys/gnark/constraint"
 	"github.com/consensys/gnark/frontend"
 	"github.com/consensys/gnark/frontend/cs/scs"
 	"github.com/consensys/gnark/logger"
-	"github.com/consensys/gnark/test"
 	"github.com/pkg/profile"
 	"github.com/spf13/cobra"
 	"github.com/zkCollective/zk-Harness/frameworks/gnark/circuits"
@@ -42,6 +42,8 @@ var plonkCmd = &cobra.Command{
 	Run:   runPlonk,
 }
 
+var plonkOptions *util.BackendOptions
+
 func runPlonk(plonkCmd *cobra.Command, args []string) {
 
 	log := logger.Logger()
@@ -49,13 +51,19 @@ func runPlonk(plonkCmd *cobra.Command, args []string) {
 
 	var filename = *cfg.OutputPath
 
+	*cfg.Backend = "plonk"
 	if err := parser.ParseFlags(cfg); err != nil {
 		fmt.Println("error: ", err.Error())
 		plonkCmd.Help()
 		os.Exit(-1)
 	}
+	if err := parseBackendOptions(plonkOptions); err != nil {
+		fmt.Println("error: ", err.Error())
+		plonkCmd.Help()
+		os.Exit(-1)
+	}
 
-	writeResults := func(took time.Duration, ccs constraint.ConstraintSystem, proof_size int) {
+	write := func(operation string, took time.Duration, ccs constraint.ConstraintSystem, proof_size int) {
 
 		// check memory usage, max ram requested from OS
 		var m runtime.MemStats
@@ -69,14 +77,16 @@ func runPlonk(plonkCmd *cobra.Command, args []string) {
 			Curve:             parser.CurveID.String(),
 			Circuit:           *cfg.Circuit,
 			Input:             *cfg.InputPath,
-			Operation:         *cfg.Algo,
+			Operation:         operation,
 			NbConstraints:     ccs.GetNbConstraints(),
 			NbSecretVariables: secret,
 			NbPublicVariables: public,
+			NbCommitments:     util.NbCommitments(ccs),
 			ProofSize:         proof_size,
 			MaxRAM:            m.Sys,
 			Count:             *cfg.Count,
 			RunTime:           took.Microseconds(),
+			Options:           plonkOptions.String(),
 		}
 
 		if err := util.WriteData("csv", bData, filename); err != nil {
@@ -84,8 +94,39 @@ func runPlonk(plonkCmd *cobra.Command, args []string) {
 		}
 	}
 
+	writeResults := func(took time.Duration, ccs constraint.ConstraintSystem, proof_size int) {
+		write(*cfg.Algo, took, ccs, proof_size)
+	}
+
+	opts := []util.BenchOption{util.WithInput(*cfg.InputPath)}
+	if !plonkOptions.IsDefault() {
+		opts = append(opts, util.WithBackendOptions(*plonkOptions, parser.CurveID))
+	}
+	if *cfg.Phases {
+		opts = append(opts, util.WithPhases(func(phase string, took time.Duration, ccs constraint.ConstraintSystem) {
+			write(*cfg.Algo+"_"+phase, took, ccs, 0)
+		}))
+	}
+	if *cfg.MemTimeline > 0 {
+		opts = append(opts, util.WithMemTimeline(*cfg.MemTimeline, writeMemTimeline(filename)))
+	}
+	opts = append(opts, util.WithSRS(*cfg.SRS, func(phase string, took time.Duration, ccs constraint.ConstraintSystem) {
+		write("setup_"+phase, took, ccs, 0)
+	}))
+	if *cfg.Algo == "serialize" {
+		opts = append(opts, util.WithSerialization(serialWriter("plonk", filename)))
+	}
+	if *cfg.Algo == "export-solidity" {
+		opts = append(opts, util.WithSolidity(solidityPath(filename, "plonk"), gasWriter("plonk", filename)))
+	}
 	// Run Benchmarks for Groth16 on given specification
-	benchPlonk(writeResults, *cfg.Algo, *cfg.Count, *cfg.CircuitSize, *cfg.Circuit, util.WithInput(*cfg.InputPath))
+	if len(parser.Threads) > 0 {
+		sweepThreads("plonk", func(fnWrite util.WriteFunction) {
+			benchPlonk(fnWrite, *cfg.Algo, *cfg.Count, *cfg.CircuitSize, *cfg.Circuit, opts...)
+		})
+		return
+	}
+	benchPlonk(writeResults, *cfg.Algo, *cfg.Count, *cfg.CircuitSize, *cfg.Circuit, opts...)
 }
 
 func benchPlonk(fnWrite util.WriteFunction, falgo string, fcount int, fcircuitSize int, fcircuit string, opts ...util.BenchOption) {
@@ -99,16 +140,23 @@ func benchPlonk(fnWrite util.WriteFunction, falgo string, fcount int, fcircuitSi
 	}
 
 	var (
-		start time.Time
-		took  time.Duration
-		prof  interface{ Stop() }
+		start    time.Time
+		last     time.Time
+		took     time.Duration
+		prof     interface{ Stop() }
+		timeline *util.Timeline
 	)
 
 	startProfile := func() {
 		start = time.Now()
+		last = start
 		if parser.P != nil {
 			prof = profile.Start(parser.P, profile.ProfilePath("."), profile.NoShutdownHook)
 		}
+		if opt.MemTimeline > 0 {
+			// prover phases are marked when their timings are reported
+			timeline = util.StartTimeline(opt.MemTimeline, falgo == "prove" && opt.WritePhase != nil)
+		}
 	}
 
 	stopProfile := func() {
@@ -116,13 +164,24 @@ func benchPlonk(fnWrite util.WriteFunction, falgo string, fcount int, fcircuitSi
 		if parser.P != nil {
 			prof.Stop()
 		}
+		if timeline != nil {
+			opt.WriteMemory(falgo, timeline.Stop())
+		}
 		took /= time.Duration(fcount)
 	}
 
+	// sample records the time of an iteration of the timed region, stored with the result by --db
+	sample := func() {
+		now := time.Now()
+		util.AddSample(now.Sub(last))
+		last = now
+	}
+
 	circuit := parser.C.Circuit(fcircuitSize,
 		fcircuit,
 		circuits.WithInputCircuit(opt.InputPath),
-		circuits.WithVKCircuit(opt.VerifyingKey))
+		circuits.WithInnerCCSCircuit(opt.CCS),
+		circuits.WithNbProofsCircuit(len(opt.Proofs)))
 
 	if falgo == "compile" {
 		startProfile()
@@ -130,6 +189,7 @@ func benchPlonk(fnWrite util.WriteFunction, falgo string, fcount int, fcircuitSi
 		var ccs constraint.ConstraintSystem
 		for i := 0; i < fcount; i++ {
 			ccs, err = frontend.Compile(parser.CurveID.ScalarField(), scs.NewBuilder, circuit, frontend.WithCapacity(fcircuitSize))
+			sample()
 		}
 		stopProfile()
 		assertNoError(err)
@@ -144,15 +204,19 @@ func benchPlonk(fnWrite util.WriteFunction, falgo string, fcount int, fcircuitSi
 	ccs, err := frontend.Compile(parser.CurveID.ScalarField(), scs.NewBuilder, circuit, frontend.WithCapacity(fcircuitSize))
 	assertNoError(err)
 
-	// create srs
-	srs, err := test.NewKZGSRS(ccs)
-	assertNoError(err)
+	// the srs steps are reported with the setup
+	var fnWriteSRS util.PhaseWriteFunction
+	if falgo == "setup" {
+		fnWriteSRS = opt.WriteSRS
+	}
+	srs, srsLagrange := newPlonkSRS(ccs, opt.SRSPath, fnWriteSRS)
 
 	if falgo == "setup" {
 		startProfile()
 		var err error
 		for i := 0; i < fcount; i++ {
-			_, _, err = plonk.Setup(ccs, srs)
+			_, _, err = plonk.Setup(ccs, srs, srsLagrange)
+			sample()
 		}
 		stopProfile()
 		assertNoError(err)
@@ -171,7 +235,9 @@ func benchPlonk(fnWrite util.WriteFunction, falgo string, fcount int, fcircuitSi
 				circuits.WithInputWitness(opt.InputPath),
 				circuits.WithVK(opt.VerifyingKey),
 				circuits.WithProof(opt.Proof),
-				circuits.WithWitness(opt.Witness))
+				circuits.WithWitness(opt.Witness),
+				circuits.WithProofs(opt.Proofs, opt.Witnesses))
+			sample()
 		}
 		stopProfile()
 		assertNoError(err)
@@ -190,22 +256,56 @@ func benchPlonk(fnWrite util.WriteFunction, falgo string, fcount int, fcircuitSi
 		circuits.WithInputWitness(opt.InputPath),
 		circuits.WithVK(opt.VerifyingKey),
 		circuits.WithProof(opt.Proof),
-		circuits.WithWitness(opt.Witness))
+		circuits.WithWitness(opt.Witness),
+		circuits.WithProofs(opt.Proofs, opt.Witnesses))
 
-	pk, vk, err := plonk.Setup(ccs, srs)
+	pk, vk, err := plonk.Setup(ccs, srs, srsLagrange)
 	assertNoError(err)
 
+	if falgo == "serialize" {
+		proof, err := plonk.Prove(ccs, pk, witness, opt.ProverOpts...)
+		assertNoError(err)
+		publicWitness, err := witness.Public()
+		assertNoError(err)
+		benchSerialization(opt.WriteSerial, fcount, []serialObject{
+			{"proof", proof, func() io.ReaderFrom { return plonk.NewProof(parser.CurveID) }},
+			{"pk", pk, func() io.ReaderFrom { return plonk.NewProvingKey(parser.CurveID) }},
+			{"vk", vk, func() io.ReaderFrom { return plonk.NewVerifyingKey(parser.CurveID) }},
+			{"ccs", ccs, func() io.ReaderFrom { return plonk.NewCS(parser.CurveID) }},
+			{"witness", witness, newWitness},
+			{"publicWitness", publicWitness, newWitness},
+		})
+		return
+	}
+
+	if falgo == "export-solidity" {
+		proof, err := plonk.Prove(ccs, pk, witness, opt.ProverOpts...)
+		assertNoError(err)
+		publicWitness, err := witness.Public()
+		assertNoError(err)
+		calldata := plonkCalldata(proof.(*plonk_bn254.Proof).MarshalSolidity(), publicWitness)
+		benchSolidity(opt.WriteGas, opt.SolidityPath, ccs, vk.(solidityExporter), "PlonkVerifier", "Verify", calldata)
+		return
+	}
+
 	if falgo == "prove" {
 		fmt.Println("BENCHMARK PROOF GENERATION")
-		var proof interface{}
+		var proof plonk.Proof
 		startProfile()
 		for i := 0; i < fcount; i++ {
-			proof, err = plonk.Prove(ccs, pk, witness)
+			proof, err = plonk.Prove(ccs, pk, witness, opt.ProverOpts...)
+			sample()
 		}
 		stopProfile()
 		assertNoError(err)
-		proof_size := size.Of(proof)
+		proof_size := util.SerializedSize(proof)
 		fnWrite(took, ccs, proof_size)
+		if opt.WritePhase != nil {
+			writeProvePhases(opt.WritePhase, fcount, ccs, func() error {
+				_, err := plonk.Prove(ccs, pk, witness, opt.ProverOpts...)
+				return err
+			})
+		}
 		return
 	}
 
@@ -213,7 +313,7 @@ func benchPlonk(fnWrite util.WriteFunction, falgo string, fcount int, fcircuitSi
 		panic("algo at this stage should be verify")
 	}
 
-	proof, err := plonk.Prove(ccs, pk, witness)
+	proof, err := plonk.Prove(ccs, pk, witness, opt.ProverOpts...)
 	assertNoError(err)
 
 	publicWitness, err := witness.Public()
@@ -222,9 +322,14 @@ func benchPlonk(fnWrite util.WriteFunction, falgo string, fcount int, fcircuitSi
 	fmt.Println("BENCHMARK PROOF VERIFICATION")
 	startProfile()
 	for i := 0; i < fcount; i++ {
-		err = plonk.Verify(proof, vk, publicWitness)
+		err = plonk.Verify(proof, vk, publicWitness, opt.VerifierOpts...)
+		sample()
 	}
 	stopProfile()
 	assertNoError(err)
 	fnWrite(took, ccs, 0)
 }
+
+func init() {
+	plonkOptions = backendFlags(plonkCmd, "plonk")
+}
@@ -0,0 +1,87 @@
+package cmd
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/spf13/cobra"
+	"github.com/zkCollective/zk-Harness/frameworks/gnark/util"
+)
+
+// queryCmd represents the query command
+var queryCmd = &cobra.Command{
+	Use:   "query",
+	Short: "filters and aggregates the results stored in --db, written as CSV or JSON for the plots",
+	Run:   runQuery,
+}
+
+var (
+	fQueryWhere        *map[string]string
+	fQueryLastVersions *int
+	fQueryGroupBy      *[]string
+	fQueryFormat       *string
+)
+
+func runQuery(cmd *cobra.Command, args []string) {
+	if *cfg.DB == "none" {
+		fmt.Println("error: missing database, set --db")
+		cmd.Help()
+		os.Exit(-1)
+	}
+	if *fQueryFormat != "csv" && *fQueryFormat != "json" {
+		fmt.Println("error: invalid format, must be csv or json")
+		cmd.Help()
+		os.Exit(-1)
+	}
+	if _, err := os.Stat(*cfg.DB); err != nil {
+		fmt.Println("error: ", err.Error())
+		os.Exit(-1)
+	}
+
+	db, err := util.OpenDB(*cfg.DB)
+	assertNoError(err)
+	defer db.Close()
+	records, err := db.Query(util.Query{
+		Where:        *fQueryWhere,
+		LastVersions: *fQueryLastVersions,
+		GroupBy:      *fQueryGroupBy,
+	})
+	if err != nil {
+		fmt.Println("error: ", err.Error())
+		os.Exit(-1)
+	}
+
+	var w io.Writer = os.Stdout
+	if *cfg.OutputPath != "None" {
+		f, err := os.Create(*cfg.OutputPath)
+		assertNoError(err)
+		defer f.Close()
+		w = f
+	}
+	if *fQueryFormat == "csv" {
+		assertNoError(csv.NewWriter(w).WriteAll(records))
+		return
+	}
+	// the rows as objects, e.g. for pandas.read_json
+	rows := []map[string]string{}
+	for _, record := range records[1:] {
+		row := make(map[string]string, len(record))
+		for i, column := range records[0] {
+			row[column] = record[i]
+		}
+		rows = append(rows, row)
+	}
+	assertNoError(json.NewEncoder(w).Encode(rows))
+}
+
+func init() {
+	fQueryWhere = queryCmd.Flags().StringToString("where", nil, "columns of the run or of the results and the value they must have, e.g. circuit=sha2,curve=bn254,operation=prove")
+	fQueryLastVersions = queryCmd.Flags().Int("last-versions", 0, "keep the results of the last n versions of gnark, ordered by version")
+	fQueryGroupBy = queryCmd.Flags().StringSlice("group-by", nil, "columns aggregating the times of the results, e.g. gnark,host, reporting their mean, median, min, max and stddev")
+	fQueryFormat = queryCmd.Flags().String("format", "csv", "format of the output, csv or json")
+
+	rootCmd.AddCommand(queryCmd)
+}
@@ -2,18 +2,27 @@ package cmd
 
 import (
 	"fmt"
+	"io"
 	"os"
 	"runtime"
 	"time"
 
 	"github.com/consensys/gnark-crypto/ecc"
+	"github.com/consensys/gnark/backend"
 	"github.com/consensys/gnark/backend/groth16"
+	"github.com/consensys/gnark/backend/plonk"
+	"github.com/consensys/gnark/backend/witness"
 	"github.com/consensys/gnark/constraint"
 	"github.com/consensys/gnark/frontend"
 	"github.com/consensys/gnark/frontend/cs/r1cs"
+	"github.com/consensys/gnark/frontend/cs/scs"
 	"github.com/consensys/gnark/logger"
+	stdgroth16 "github.com/consensys/gnark/std/recursion/groth16"
+	stdplonk "github.com/consensys/gnark/std/recursion/plonk"
+	"github.com/consensys/gnark/test/unsafekzg"
 	"github.com/spf13/cobra"
 	"github.com/zkCollective/zk-Harness/frameworks/gnark/circuits"
+	"github.com/zkCollective/zk-Harness/frameworks/gnark/circuits/verifier"
 	"github.com/zkCollective/zk-Harness/frameworks/gnark/parser"
 	"github.com/zkCollective/zk-Harness/frameworks/gnark/util"
 )
@@ -24,158 +33,297 @@ var recursionCmd = &cobra.Command{
 	Run:   runOneStep,
 }
 
-var witness frontend.Variable
-
 var recursiveCircuit string
 
-func computeInnerProofG16(fcircuitSize int, fcircuit string, finputPath string, finnerCurveID ecc.ID) (groth16.VerifyingKey, groth16.Proof, constraint.ConstraintSystem) {
+// computeInnerProofsG16 proves the inner circuit nbProofs times under the same verifying key, such that its
+// proofs can be verified in a circuit over the outer curve, and returns the inner witness whose public part
+// the outer circuit verifies
+func computeInnerProofsG16(nbProofs int, fcircuitSize int, fcircuit string, finputPath string, finnerCurveID ecc.ID, fouterCurveID ecc.ID) (groth16.VerifyingKey, []interface{}, constraint.ConstraintSystem, witness.Witness) {
 	circuit := parser.C.Circuit(fcircuitSize, fcircuit, circuits.WithInputCircuit(finputPath))
 	ccs, err := frontend.Compile(finnerCurveID.ScalarField(), r1cs.NewBuilder, circuit, frontend.WithCapacity(fcircuitSize))
+	assertNoError(err)
 	witness := parser.C.Witness(fcircuitSize, finnerCurveID, fcircuit, circuits.WithInputWitness(finputPath))
 	pk, vk, err := groth16.Setup(ccs)
 	assertNoError(err)
-	proof, err := groth16.Prove(ccs, pk, witness)
+	publicWitness, err := witness.Public()
+	assertNoError(err)
+	proofs := make([]interface{}, nbProofs)
+	for i := range proofs {
+		// commitments of the inner proof must be hashed to field the way the in-circuit verifier does
+		proof, err := groth16.Prove(ccs, pk, witness, stdgroth16.GetNativeProverOptions(fouterCurveID.ScalarField(), finnerCurveID.ScalarField()))
+		assertNoError(err)
+		// Check that proof verifies before continuing
+		if err := groth16.Verify(proof, vk, publicWitness, stdgroth16.GetNativeVerifierOptions(fouterCurveID.ScalarField(), finnerCurveID.ScalarField())); err != nil {
+			panic(err)
+		}
+		proofs[i] = proof
+	}
+	return vk, proofs, ccs, witness
+}
+
+// computeInnerProofsPlonk is the PlonK counterpart of computeInnerProofsG16
+func computeInnerProofsPlonk(nbProofs int, fcircuitSize int, fcircuit string, finputPath string, finnerCurveID ecc.ID, fouterCurveID ecc.ID) (plonk.VerifyingKey, []interface{}, constraint.ConstraintSystem, witness.Witness) {
+	circuit := parser.C.Circuit(fcircuitSize, fcircuit, circuits.WithInputCircuit(finputPath))
+	ccs, err := frontend.Compile(finnerCurveID.ScalarField(), scs.NewBuilder, circuit, frontend.WithCapacity(fcircuitSize))
+	assertNoError(err)
+	witness := parser.C.Witness(fcircuitSize, finnerCurveID, fcircuit, circuits.WithInputWitness(finputPath))
+	srs, srsLagrange, err := unsafekzg.NewSRS(ccs)
+	assertNoError(err)
+	pk, vk, err := plonk.Setup(ccs, srs, srsLagrange)
 	assertNoError(err)
 	publicWitness, err := witness.Public()
 	assertNoError(err)
-	// Check that proof verifies before continuing
-	if err := groth16.Verify(proof, vk, publicWitness); err != nil {
-		panic(err)
+	proofs := make([]interface{}, nbProofs)
+	for i := range proofs {
+		// the challenges of the inner proof must be derived the way the in-circuit verifier does
+		proof, err := plonk.Prove(ccs, pk, witness, stdplonk.GetNativeProverOptions(fouterCurveID.ScalarField(), finnerCurveID.ScalarField()))
+		assertNoError(err)
+		// Check that proof verifies before continuing
+		if err := plonk.Verify(proof, vk, publicWitness, stdplonk.GetNativeVerifierOptions(fouterCurveID.ScalarField(), finnerCurveID.ScalarField())); err != nil {
+			panic(err)
+		}
+		proofs[i] = proof
 	}
-	return vk, proof, ccs
+	return vk, proofs, ccs, witness
 }
 
-func runOneStep(cmd *cobra.Command, args []string) {
-	log := logger.Logger()
-	log.Info().Msg("Benchmarking " + *cfg.Circuit + " - gnark, recursion: " + *cfg.Algo + " " + *cfg.Curve + " " + *cfg.InputPath)
+// computeInnerProofs proves the inner circuit nbProofs times with the inner backend
+func computeInnerProofs(nbProofs int, finnerBackend string) (interface{}, []interface{}, constraint.ConstraintSystem, witness.Witness) {
+	if finnerBackend == "plonk" {
+		return computeInnerProofsPlonk(nbProofs, *cfg.CircuitSize, *cfg.Circuit, *cfg.InputPath, parser.InnerCurveID, parser.CurveID)
+	}
+	return computeInnerProofsG16(nbProofs, *cfg.CircuitSize, *cfg.Circuit, *cfg.InputPath, parser.InnerCurveID, parser.CurveID)
+}
 
-	var filename = *cfg.OutputPath
+// nativeInnerCurveID and nativeOuterCurveID are the 2-chain of the reference verifier of the emulated recursion,
+// whatever its inner curve: gnark has no 2-chain over BN254 or BW6_761, and the BLS12 family of BLS12_381 has
+// the 2-chain of BLS12_377
+var nativeInnerCurveID, nativeOuterCurveID = ecc.BLS12_377, ecc.BW6_761
 
-	if err := parser.ParseFlags(cfg); err != nil {
-		fmt.Println("error: ", err.Error())
-		cmd.Help()
-		os.Exit(-1)
+// compileNativeVerifier compiles the verifier, named after the inner backend and curve plus the suffix, of the
+// inner constraint system over the native 2-chain, the reference for the non-native overhead of the emulated
+// recursion. The reference is sized after the inner constraint system of the emulated recursion, compiled over
+// the scalar field of its inner curve, such that both verify the same public inputs and commitments
+func compileNativeVerifier(fcircuitSize int, innerCCS constraint.ConstraintSystem, finnerBackend string, fouterBackend string, suffix string, nbProofs int) constraint.ConstraintSystem {
+	builders := map[string]frontend.NewBuilder{"groth16": r1cs.NewBuilder, "plonk": scs.NewBuilder}
+
+	circuit := parser.C.Circuit(fcircuitSize, verifier.Name(finnerBackend, nativeInnerCurveID)+suffix, circuits.WithInnerCCSCircuit(innerCCS), circuits.WithNbProofsCircuit(nbProofs))
+	ccs, err := frontend.Compile(nativeOuterCurveID.ScalarField(), builders[fouterBackend], circuit, frontend.WithCapacity(fcircuitSize))
+	assertNoError(err)
+	return ccs
+}
+
+// recursionData fills the record of a recursion benchmark of the outer constraint system, with the
+// backends and curves of the flags
+func recursionData(category string, innerCCS constraint.ConstraintSystem, ccs constraint.ConstraintSystem, took time.Duration, proof_size int) util.BenchDataRecursion {
+
+	// check memory usage, max ram requested from OS
+	var m runtime.MemStats
+	runtime.ReadMemStats(&m)
+
+	_, secret, public := ccs.GetNbVariables()
+
+	return util.BenchDataRecursion{
+		Framework:           "gnark",
+		Category:            category,
+		InnerBackend:        *cfg.InnerBackend,
+		InnerCurve:          parser.InnerCurveID.String(),
+		OuterBackend:        *cfg.OuterBackend,
+		OuterCurve:          parser.CurveID.String(),
+		Circuit:             *cfg.Circuit,
+		Input:               *cfg.InputPath,
+		Operation:           *cfg.Algo,
+		InnerNbConstraints:  innerCCS.GetNbConstraints(),
+		NbConstraints:       ccs.GetNbConstraints(),
+		NbSecretVariables:   secret,
+		NbPublicVariables:   public,
+		ProofSize:           proof_size,
+		MaxRAM:              (m.Sys / 1024 / 1024),
+		Count:               *cfg.Count,
+		RunTime:             took.Milliseconds(),
+		NbProofs:            1,
+		Aggregation:         "none",
+		ConstraintsPerProof: float64(ccs.GetNbConstraints()),
+		Emulated:            parser.Emulated,
+		NativeInnerCurve:    parser.InnerCurveID.String(),
+		NativeOuterCurve:    parser.CurveID.String(),
+		NativeNbConstraints: ccs.GetNbConstraints(),
+		Overhead:            1,
+		Level:               1,
 	}
+}
+
+// setOverhead sets the non-native overhead of the record relative to nativeCCS, if the recursion is emulated
+func setOverhead(bData *util.BenchDataRecursion, nativeCCS constraint.ConstraintSystem) {
+	if nativeCCS != nil {
+		bData.NativeInnerCurve = nativeInnerCurveID.String()
+		bData.NativeOuterCurve = nativeOuterCurveID.String()
+		bData.NativeNbConstraints = nativeCCS.GetNbConstraints()
+	}
+	bData.Overhead = float64(bData.NbConstraints) / float64(bData.NativeNbConstraints)
+}
 
-	var data map[string]interface{}
-	if *cfg.InputPath != "none" {
-		var err error
-		data, err = util.ReadFromInputPath(*cfg.InputPath)
-		if err != nil {
+// recursionWriter returns the function writing the records of the recursion and aggregation benchmarks,
+// the non-native overhead being relative to nativeCCS if the recursion is emulated
+func recursionWriter(filename string, category string, innerCCS constraint.ConstraintSystem, nativeCCS constraint.ConstraintSystem, nbProofs int, aggregation string) util.WriteFunction {
+	return func(took time.Duration, ccs constraint.ConstraintSystem, proof_size int) {
+		bData := recursionData(category, innerCCS, ccs, took, proof_size)
+		bData.NbProofs = nbProofs
+		bData.Aggregation = aggregation
+		bData.ConstraintsPerProof = float64(ccs.GetNbConstraints()) / float64(nbProofs)
+		setOverhead(&bData, nativeCCS)
+
+		if err := util.WriteData("csv", bData, filename); err != nil {
 			panic(err)
 		}
 	}
+}
 
-	// Set inner curve based on outer curve
-	switch *cfg.Curve {
-	case "bw6_761":
-		parser.InnerCurveID = ecc.BLS12_377
-		recursiveCircuit = "groth16_bls12377"
-	case "bw6_633":
-		parser.InnerCurveID = ecc.BLS24_315
-		recursiveCircuit = "groth16_bls24315"
+// proveLevel sets up and proves the circuit of one level of a recursion chain with the backend, averaging the
+// prove time over --count. Unless nextCurveID is unknown, the proof is verifiable in a circuit over the next curve
+func proveLevel(fbackend string, ccs constraint.ConstraintSystem, witness witness.Witness, curveID ecc.ID, nextCurveID ecc.ID) (interface{}, interface{}, time.Duration) {
+	publicWitness, err := witness.Public()
+	assertNoError(err)
+
+	var (
+		vk, proof interface{}
+		took      time.Duration
+	)
+	switch fbackend {
+	case "groth16":
+		var proverOpts []backend.ProverOption
+		var verifierOpts []backend.VerifierOption
+		if nextCurveID != ecc.UNKNOWN {
+			proverOpts = append(proverOpts, stdgroth16.GetNativeProverOptions(nextCurveID.ScalarField(), curveID.ScalarField()))
+			verifierOpts = append(verifierOpts, stdgroth16.GetNativeVerifierOptions(nextCurveID.ScalarField(), curveID.ScalarField()))
+		}
+		pk, groth16Vk, err := groth16.Setup(ccs)
+		assertNoError(err)
+		var groth16Proof groth16.Proof
+		start := time.Now()
+		for i := 0; i < *cfg.Count; i++ {
+			groth16Proof, err = groth16.Prove(ccs, pk, witness, proverOpts...)
+		}
+		took = time.Since(start)
+		assertNoError(err)
+		// the proof is verified before it is recursed on
+		assertNoError(groth16.Verify(groth16Proof, groth16Vk, publicWitness, verifierOpts...))
+		vk, proof = groth16Vk, groth16Proof
+	case "plonk":
+		var proverOpts []backend.ProverOption
+		var verifierOpts []backend.VerifierOption
+		if nextCurveID != ecc.UNKNOWN {
+			proverOpts = append(proverOpts, stdplonk.GetNativeProverOptions(nextCurveID.ScalarField(), curveID.ScalarField()))
+			verifierOpts = append(verifierOpts, stdplonk.GetNativeVerifierOptions(nextCurveID.ScalarField(), curveID.ScalarField()))
+		}
+		srs, srsLagrange, err := unsafekzg.NewSRS(ccs)
+		assertNoError(err)
+		pk, plonkVk, err := plonk.Setup(ccs, srs, srsLagrange)
+		assertNoError(err)
+		var plonkProof plonk.Proof
+		start := time.Now()
+		for i := 0; i < *cfg.Count; i++ {
+			plonkProof, err = plonk.Prove(ccs, pk, witness, proverOpts...)
+		}
+		took = time.Since(start)
+		assertNoError(err)
+		// the proof is verified before it is recursed on
+		assertNoError(plonk.Verify(plonkProof, plonkVk, publicWitness, verifierOpts...))
+		vk, proof = plonkVk, plonkProof
 	default:
-		panic("Chosen Curve not implemented for 2-Chain recursion! Must be bw6_761 or bw6_633")
+		panic("Outer backend not supported!")
 	}
+	return vk, proof, took / time.Duration(*cfg.Count)
+}
 
-	// pre-compute the inner G16 proof, return innerCCS to get num Constraints inner
-	innerVk, innerProof, innerCCS := computeInnerProofG16(*cfg.CircuitSize, *cfg.Circuit, *cfg.InputPath, parser.InnerCurveID)
-
-	writeResults := func(took time.Duration, ccs constraint.ConstraintSystem, proof_size int) {
-
-		// check memory usage, max ram requested from OS
-		var m runtime.MemStats
-		runtime.ReadMemStats(&m)
-
-		_, secret, public := ccs.GetNbVariables()
-
-		// FIXME - hardcoded inner backend
-		bData := util.BenchDataRecursion{
-			Framework:          "gnark",
-			Category:           "circuit",
-			InnerBackend:       "groth16",
-			InnerCurve:         parser.InnerCurveID.String(),
-			OuterBackend:       *cfg.OuterBackend,
-			OuterCurve:         parser.CurveID.String(),
-			Circuit:            *cfg.Circuit,
-			Input:              *cfg.InputPath,
-			Operation:          *cfg.Algo,
-			InnerNbConstraints: innerCCS.GetNbConstraints(),
-			NbConstraints:      ccs.GetNbConstraints(),
-			NbSecretVariables:  secret,
-			NbPublicVariables:  public,
-			ProofSize:          proof_size,
-			MaxRAM:             (m.Sys / 1024 / 1024),
-			Count:              *cfg.Count,
-			RunTime:            took.Milliseconds(),
+// runChain verifies the inner proof in the outer circuit and then, for every further level up to --depth,
+// the proof of the previous level in a circuit over parser.ChainCurveID, writing one record per level
+func runChain(filename string, innerVk interface{}, innerProof interface{}, innerCCS constraint.ConstraintSystem, innerWitness witness.Witness, nativeCCS constraint.ConstraintSystem) {
+	builders := map[string]frontend.NewBuilder{"groth16": r1cs.NewBuilder, "plonk": scs.NewBuilder}
+
+	innerBackend, innerCurveID, curveID, emulated := *cfg.InnerBackend, parser.InnerCurveID, parser.CurveID, parser.Emulated
+	for level := 1; level <= *cfg.Depth; level++ {
+		fmt.Println("BENCHMARK RECURSION LEVEL", level)
+		name := verifier.Name(innerBackend, innerCurveID)
+		circuit := parser.C.Circuit(*cfg.CircuitSize, name, circuits.WithInnerCCSCircuit(innerCCS))
+		ccs, err := frontend.Compile(curveID.ScalarField(), builders[*cfg.OuterBackend], circuit, frontend.WithCapacity(*cfg.CircuitSize))
+		assertNoError(err)
+		witness := parser.C.Witness(*cfg.CircuitSize,
+			curveID,
+			name,
+			circuits.WithVK(innerVk),
+			circuits.WithProof(innerProof),
+			circuits.WithWitness(innerWitness))
+
+		// the proof of the last level is not recursed on
+		nextCurveID := parser.ChainCurveID
+		if level == *cfg.Depth {
+			nextCurveID = ecc.UNKNOWN
 		}
+		vk, proof, took := proveLevel(*cfg.OuterBackend, ccs, witness, curveID, nextCurveID)
 
+		bData := recursionData("circuit", innerCCS, ccs, took, util.SerializedSize(proof.(io.WriterTo)))
+		bData.InnerBackend = innerBackend
+		bData.InnerCurve = innerCurveID.String()
+		bData.OuterCurve = curveID.String()
+		bData.NativeInnerCurve, bData.NativeOuterCurve = bData.InnerCurve, bData.OuterCurve
+		bData.Emulated = emulated
+		bData.Level = level
+		// only the first level has a 2-chain reference
+		if level == 1 {
+			setOverhead(&bData, nativeCCS)
+		}
 		if err := util.WriteData("csv", bData, filename); err != nil {
 			panic(err)
 		}
+
+		innerBackend, innerCurveID, curveID, emulated = *cfg.OuterBackend, curveID, parser.ChainCurveID, true
+		innerVk, innerProof, innerCCS, innerWitness = vk, proof, ccs, witness
+	}
+}
+
+func runOneStep(cmd *cobra.Command, args []string) {
+	log := logger.Logger()
+	log.Info().Msg("Benchmarking " + *cfg.Circuit + " - gnark, recursion " + *cfg.InnerBackend + " in " + *cfg.OuterBackend + ": " + *cfg.Algo + " " + *cfg.Curve + " " + *cfg.InputPath)
+
+	var filename = *cfg.OutputPath
+
+	if err := parser.ParseFlagsRecursion(cfg); err != nil {
+		fmt.Println("error: ", err.Error())
+		cmd.Help()
+		os.Exit(-1)
+	}
+
+	recursiveCircuit = verifier.Name(*cfg.InnerBackend, parser.InnerCurveID)
+
+	// pre-compute the inner proof, return innerCCS to get num Constraints inner
+	innerVk, innerProofs, innerCCS, innerWitness := computeInnerProofs(1, *cfg.InnerBackend)
+
+	// the emulated verifier is compared to the 2-chain verifier of the same inner circuit
+	var nativeCCS constraint.ConstraintSystem
+	if parser.Emulated {
+		nativeCCS = compileNativeVerifier(*cfg.CircuitSize, innerCCS, *cfg.InnerBackend, *cfg.OuterBackend, "", 0)
+	}
+
+	if *cfg.Depth > 1 {
+		runChain(filename, innerVk, innerProofs[0], innerCCS, innerWitness, nativeCCS)
+		return
+	}
+
+	writeResults := recursionWriter(filename, "circuit", innerCCS, nativeCCS, 1, "none")
+
+	opts := []util.BenchOption{
+		util.WithVK(innerVk),
+		util.WithProof(innerProofs[0]),
+		util.WithInnerCCS(innerCCS),
+		util.WithWitness(innerWitness),
 	}
 
 	switch *cfg.OuterBackend {
 	case "groth16":
-		switch *cfg.Circuit {
-		case "mimc":
-			witness = util.PreCalcMIMC(parser.InnerCurveID, (data["PreImage"].(string)))
-		case "cubic":
-			witness = (data["Y"].(string))
-		case "bench":
-			witness = (data["Y"].(string))
-		default:
-			panic("Chosen Circuit not implemented for Groth16 recursion!")
-		}
-		benchGroth16(
-			writeResults,
-			*cfg.Algo,
-			*cfg.Count,
-			*cfg.CircuitSize,
-			recursiveCircuit,
-			util.WithVK(innerVk),
-			util.WithProof(innerProof),
-			util.WithWitness(witness))
+		benchGroth16(writeResults, *cfg.Algo, *cfg.Count, *cfg.CircuitSize, recursiveCircuit, opts...)
 	case "plonk":
-		switch *cfg.Circuit {
-		case "mimc":
-			witness = util.PreCalcMIMC(parser.InnerCurveID, (data["PreImage"].(string)))
-		case "cubic":
-			witness = (data["Y"].(string))
-		case "bench":
-			witness = (data["Y"].(string))
-		default:
-			panic("Circuit not implemented for Plonk recursion!")
-		}
-		benchPlonk(
-			writeResults,
-			*cfg.Algo,
-			*cfg.Count,
-			*cfg.CircuitSize,
-			recursiveCircuit,
-			util.WithVK(innerVk),
-			util.WithProof(innerProof),
-			util.WithWitness(witness))
-	case "plonkFRI":
-		switch *cfg.Circuit {
-		case "mimc":
-			witness = util.PreCalcMIMC(parser.InnerCurveID, (data["PreImage"].(string)))
-		case "cubic":
-			witness = (data["Y"].(string))
-		case "bench":
-			witness = (data["Y"].(string))
-		default:
-			panic("Circuit not implemented for PlonkFRI recursion!")
-		}
-		benchPlonkFRI(
-			writeResults,
-			*cfg.Algo,
-			*cfg.Count,
-			*cfg.CircuitSize,
-			recursiveCircuit,
-			util.WithVK(innerVk),
-			util.WithProof(innerProof),
-			util.WithWitness(witness))
+		benchPlonk(writeResults, *cfg.Algo, *cfg.Count, *cfg.CircuitSize, recursiveCircuit, opts...)
 	default:
 		panic("Outer backend not supported!")
 	}
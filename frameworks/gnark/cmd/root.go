@@ -1,13 +1,17 @@
 package cmd
 
 import (
+	"crypto/rand"
+	"encoding/hex"
 	"fmt"
 	"os"
 	"strings"
+	"time"
 
 	"github.com/consensys/gnark-crypto/ecc"
 	"github.com/spf13/cobra"
 	"github.com/zkCollective/zk-Harness/frameworks/gnark/parser"
+	"github.com/zkCollective/zk-Harness/frameworks/gnark/util"
 )
 
 var cfg = parser.NewConfig()
@@ -16,6 +20,18 @@ var cfg = parser.NewConfig()
 var rootCmd = &cobra.Command{
 	Use:   "gnark-harness",
 	Short: "runs benchmarks and profiles using gnark",
+	PersistentPreRun: func(cmd *cobra.Command, args []string) {
+		if *cfg.DB == "none" {
+			return
+		}
+		if *cfg.RunID == "" {
+			// e.g. 20240102T150405Z-1a2b3c4d
+			b := make([]byte, 4)
+			rand.Read(b)
+			*cfg.RunID = time.Now().UTC().Format("20060102T150405Z") + "-" + hex.EncodeToString(b)
+		}
+		util.Sink = util.NewDBSink(*cfg.DB, *cfg.RunID)
+	},
 }
 
 // Execute adds all child commands to the root command and sets flags appropriately.
@@ -65,13 +81,23 @@ func init() {
 	cfg.CircuitSize = rootCmd.PersistentFlags().Int("size", 10000, "size of the circuit, parameter to circuit constructor")
 	cfg.Count = rootCmd.PersistentFlags().Int("count", 2, "bench count (time is averaged on number of executions)")
 	cfg.Curve = rootCmd.PersistentFlags().String("curve", "bn254", "curve name. must be "+fmt.Sprint(curves))
-	cfg.Algo = rootCmd.PersistentFlags().String("algo", "prove", "name of the algorithm to benchmark. must be compile, setup, prove or verify")
+	cfg.Algo = rootCmd.PersistentFlags().String("algo", "prove", "name of the algorithm to benchmark. must be compile, setup, witness, prove, verify, serialize or export-solidity")
 	cfg.Operation = rootCmd.PersistentFlags().String("operation", "None", "operation to benchmark")
 	cfg.Profile = rootCmd.PersistentFlags().String("profile", "none", "type of profile. must be none, trace, cpu or mem")
 
+	cfg.InnerCurve = rootCmd.PersistentFlags().String("innerCurve", "none", "Curve of the inner circuit, set by the 2-chain or bn254 or bls12_381 for the emulated recursion over bn254")
+	cfg.InnerBackend = rootCmd.PersistentFlags().String("innerBackend", "groth16", "Backend for the inner circuit. must be groth16 or plonk")
 	cfg.OuterBackend = rootCmd.PersistentFlags().String("outerBackend", "groth16", "Backend for the outer circuit")
+	cfg.Depth = rootCmd.PersistentFlags().Int("depth", 1, "number of recursion levels, the levels after the first verifying the previous proof in bn254")
 
 	cfg.OutputPath = rootCmd.PersistentFlags().String("outputPath", "None", "The output path for the log file")
+	cfg.Phases = rootCmd.PersistentFlags().Bool("phases", false, "additionally report the sub-phase timings of proof generation")
+	cfg.Threads = rootCmd.PersistentFlags().String("threads", "none", "comma separated thread counts to re-run the algorithm with, e.g. 1,2,4,8,max")
+	cfg.SRS = rootCmd.PersistentFlags().String("srs", "none", "PlonK SRS to load, a kzg.SRS serialized by gnark-crypto or a .ptau file over bn254, generated if none")
+	cfg.MemTimeline = rootCmd.PersistentFlags().Duration("mem-timeline", 0, "sample the memory over the timed region at this interval, e.g. 10ms, into a sidecar CSV per result")
+	cfg.CPUSet = rootCmd.PersistentFlags().String("cpuset", "none", "CPUs the thread sweep is pinned to, e.g. 0-7")
+	cfg.DB = rootCmd.PersistentFlags().String("db", "none", "SQLite database every result is additionally stored in, with the run, the host and the versions, e.g. results.sqlite")
+	cfg.RunID = rootCmd.PersistentFlags().String("run-id", "", "id of the run the results are stored under in --db, shared by the invocations of a campaign, generated if empty")
 
 	rootCmd.AddCommand(groth16Cmd)
 	rootCmd.AddCommand(plonkCmd)
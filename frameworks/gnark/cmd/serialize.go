@@ -0,0 +1,69 @@
+package cmd
+
+import (
+	"fmt"
+	"io"
+	"path/filepath"
+	"strings"
+
+	"github.com/consensys/gnark/backend/witness"
+	"github.com/zkCollective/zk-Harness/frameworks/gnark/parser"
+	"github.com/zkCollective/zk-Harness/frameworks/gnark/util"
+)
+
+// serialObject is an object of a backend to serialize, newObject allocating the object to read it back into
+type serialObject struct {
+	name      string
+	object    io.WriterTo
+	newObject func() io.ReaderFrom
+}
+
+// serialWriter writes a serialization record per object, encoding and operation next to the results in filename
+func serialWriter(backend string, filename string) util.SerializationWriteFunction {
+	filename = recordsPath(filename, "serialization")
+	return func(s util.Serialization) {
+		bData := util.BenchDataSerialization{
+			Framework: "gnark",
+			Category:  "serialization",
+			Backend:   backend,
+			Curve:     parser.CurveID.String(),
+			Circuit:   *cfg.Circuit,
+			Input:     *cfg.InputPath,
+			Object:    s.Object,
+			Encoding:  s.Encoding,
+			Operation: s.Operation,
+			Bytes:     s.Bytes,
+			Count:     *cfg.Count,
+			RunTime:   s.Took.Microseconds(),
+		}
+		if err := util.WriteData("csv", bData, filename); err != nil {
+			panic(err)
+		}
+	}
+}
+
+// recordsPath names the CSV of the records of a category with their own columns next to the results in
+// filename, e.g. results_serialization.csv, such that they do not share the header of the circuit records
+func recordsPath(filename string, category string) string {
+	ext := filepath.Ext(filename)
+	return strings.TrimSuffix(filename, ext) + "_" + category + ext
+}
+
+// benchSerialization benchmarks writing and reading back each object
+func benchSerialization(fnWriteSerial util.SerializationWriteFunction, fcount int, objects []serialObject) {
+	fmt.Println("BENCHMARK SERIALIZATION")
+	for _, o := range objects {
+		res, err := util.Serialize(o.name, o.object, o.newObject, fcount)
+		assertNoError(err)
+		for _, s := range res {
+			fnWriteSerial(s)
+		}
+	}
+}
+
+// newWitness allocates a witness over the scalar field of the curve to read a full or public witness into
+func newWitness() io.ReaderFrom {
+	w, err := witness.New(parser.CurveID.ScalarField())
+	assertNoError(err)
+	return w
+}
@@ -0,0 +1,70 @@
+package cmd
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"os"
+	"os/signal"
+
+	"github.com/consensys/gnark/logger"
+	"github.com/spf13/cobra"
+	"github.com/zkCollective/zk-Harness/frameworks/gnark/service"
+)
+
+// serveCmd represents the serve command
+var serveCmd = &cobra.Command{
+	Use:   "serve",
+	Short: "serves an HTTP API running the benchmark specs of input/config/gnark one job at a time",
+	Run:   runServe,
+}
+
+var (
+	fServeAddr    *string
+	fServeQueue   *int
+	fServeJobsDir *string
+)
+
+func runServe(cmd *cobra.Command, args []string) {
+	log := logger.Logger()
+
+	if *fServeQueue < 1 {
+		fmt.Println("error: the queue must hold at least one job")
+		cmd.Help()
+		os.Exit(-1)
+	}
+	// every benchmark runs in a child process of the harness, as with the python harness
+	path, err := os.Executable()
+	assertNoError(err)
+
+	// the input paths of the specs are relative to the root of the repository, as for --input
+	s := service.New(service.ExecRunner(path), "../../", *fServeJobsDir, *fServeQueue)
+	server := &http.Server{Addr: *fServeAddr, Handler: s.Handler()}
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt)
+	defer stop()
+	go func() {
+		<-ctx.Done()
+		server.Shutdown(context.Background())
+	}()
+	go func() {
+		if err := s.Run(ctx); err != nil && !errors.Is(err, context.Canceled) {
+			fmt.Println("error: ", err.Error())
+			os.Exit(-1)
+		}
+	}()
+
+	log.Info().Msg("Serving benchmark jobs on " + *fServeAddr)
+	if err := server.ListenAndServe(); !errors.Is(err, http.ErrServerClosed) {
+		assertNoError(err)
+	}
+}
+
+func init() {
+	fServeAddr = serveCmd.Flags().String("addr", "localhost:8080", "address the HTTP API listens on, the local host only by default")
+	fServeQueue = serveCmd.Flags().Int("queue", 16, "number of jobs waiting to run before submissions are refused")
+	fServeJobsDir = serveCmd.Flags().String("jobs-dir", "tmp/jobs", "directory the results of the jobs are written to, a CSV per job")
+
+	rootCmd.AddCommand(serveCmd)
+}
@@ -0,0 +1,160 @@
+package cmd
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"math/big"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+
+	"github.com/consensys/gnark-crypto/ecc/bn254/fr"
+	"github.com/consensys/gnark/backend/witness"
+	"github.com/consensys/gnark/constraint"
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/zkCollective/zk-Harness/frameworks/gnark/parser"
+	"github.com/zkCollective/zk-Harness/frameworks/gnark/util"
+)
+
+// solidityExporter is a verifying key with a Solidity verifier, those of both backends over bn254
+type solidityExporter interface {
+	ExportSolidity(w io.Writer) error
+}
+
+// solidityCalldata returns the arguments of the verifying method of the compiled contract
+type solidityCalldata func(method abi.Method) ([]interface{}, error)
+
+// gasWriter writes a gas record per circuit next to the results in filename
+func gasWriter(backend string, filename string) util.GasWriteFunction {
+	filename = recordsPath(filename, "solidity")
+	return func(cost util.GasCost, bytecodeSize int, ccs constraint.ConstraintSystem) {
+		bData := util.BenchDataSolidity{
+			Framework:     "gnark",
+			Category:      "solidity",
+			Backend:       backend,
+			Curve:         parser.CurveID.String(),
+			Circuit:       *cfg.Circuit,
+			Input:         *cfg.InputPath,
+			NbConstraints: ccs.GetNbConstraints(),
+			NbPublic:      ccs.GetNbPublicVariables() - 1,
+			BytecodeSize:  bytecodeSize,
+			DeployGas:     cost.Deploy,
+			VerifyGas:     cost.Call,
+			CalldataGas:   cost.Calldata,
+			ExecutionGas:  cost.Execution,
+		}
+		if err := util.WriteData("csv", bData, filename); err != nil {
+			panic(err)
+		}
+	}
+}
+
+// solidityPath names the verifier contract of a backend after the results in filename and the input
+func solidityPath(filename string, backend string) string {
+	stem := strings.TrimSuffix(filename, filepath.Ext(filename))
+	input := filepath.Base(*cfg.InputPath)
+	input = strings.TrimSuffix(input, filepath.Ext(input))
+	return stem + "_" + backend + "_" + input + "_verifier.sol"
+}
+
+// benchSolidity writes the Solidity verifier of vk to path and, if solc is found, compiles the contract name
+// and reports the gas of its deployment and of a call to method with the calldata of a valid proof
+func benchSolidity(fnWriteGas util.GasWriteFunction, path string, ccs constraint.ConstraintSystem,
+	vk solidityExporter, name string, method string, calldata solidityCalldata) {
+	fmt.Println("BENCHMARK SOLIDITY VERIFIER")
+	f, err := os.Create(path)
+	assertNoError(err)
+	assertNoError(vk.ExportSolidity(f))
+	assertNoError(f.Close())
+
+	contract, err := util.CompileSolidity(path, name)
+	if errors.Is(err, util.ErrNoSolc) {
+		fmt.Println("solc not found, " + path + " is exported without gas measurement")
+		return
+	}
+	assertNoError(err)
+
+	m, ok := contract.ABI.Methods[method]
+	if !ok {
+		panic("contract " + name + " has no method " + method)
+	}
+	args, err := calldata(m)
+	assertNoError(err)
+	cost, err := util.MeasureGas(contract, method, args...)
+	assertNoError(err)
+	if fnWriteGas != nil {
+		fnWriteGas(cost, len(contract.Bin), ccs)
+	}
+}
+
+// groth16Calldata spreads the words of the proof, with its commitments, and the public inputs over the
+// fixed size arrays of the arguments of verifyProof, as laid out by the Solidity verifier of gnark
+func groth16Calldata(proof []byte, publicWitness witness.Witness) solidityCalldata {
+	return func(method abi.Method) ([]interface{}, error) {
+		// Ar, Bs and Krs then, with commitments, their number on 4 bytes, the commitments and their proof of knowledge
+		words := solidityWords(proof[:8*fr.Bytes])
+		if len(proof) > 8*fr.Bytes {
+			words = append(words, solidityWords(proof[8*fr.Bytes+4:])...)
+		}
+		public, err := solidityPublicInputs(publicWitness)
+		if err != nil {
+			return nil, err
+		}
+		words = append(words, public...)
+
+		args := make([]interface{}, len(method.Inputs))
+		for i, input := range method.Inputs {
+			if input.Type.T != abi.ArrayTy || input.Type.Size > len(words) {
+				return nil, fmt.Errorf("%s: unexpected argument %s", method.Name, input.Name)
+			}
+			arg := reflect.New(input.Type.GetType()).Elem()
+			for j := 0; j < input.Type.Size; j++ {
+				arg.Index(j).Set(reflect.ValueOf(words[j]))
+			}
+			words = words[input.Type.Size:]
+			args[i] = arg.Interface()
+		}
+		if len(words) != 0 {
+			return nil, fmt.Errorf("%s: %d words of calldata left", method.Name, len(words))
+		}
+		return args, nil
+	}
+}
+
+// plonkCalldata returns the serialized proof and the public inputs, the arguments of Verify
+func plonkCalldata(proof []byte, publicWitness witness.Witness) solidityCalldata {
+	return func(method abi.Method) ([]interface{}, error) {
+		if len(method.Inputs) != 2 || method.Inputs[0].Type.T != abi.BytesTy || method.Inputs[1].Type.T != abi.SliceTy {
+			return nil, fmt.Errorf("%s: unexpected arguments, expected bytes and uint256[]", method.Name)
+		}
+		public, err := solidityPublicInputs(publicWitness)
+		if err != nil {
+			return nil, err
+		}
+		return []interface{}{proof, public}, nil
+	}
+}
+
+// solidityWords splits b into big endian words of 32 bytes
+func solidityWords(b []byte) []*big.Int {
+	words := make([]*big.Int, 0, len(b)/fr.Bytes)
+	for i := 0; i+fr.Bytes <= len(b); i += fr.Bytes {
+		words = append(words, new(big.Int).SetBytes(b[i:i+fr.Bytes]))
+	}
+	return words
+}
+
+// solidityPublicInputs returns the public inputs of the witness over bn254 as integers
+func solidityPublicInputs(publicWitness witness.Witness) ([]*big.Int, error) {
+	vector, ok := publicWitness.Vector().(fr.Vector)
+	if !ok {
+		return nil, errors.New("public witness is not over bn254")
+	}
+	public := make([]*big.Int, len(vector))
+	for i := range vector {
+		public[i] = vector[i].BigInt(new(big.Int))
+	}
+	return public, nil
+}
@@ -0,0 +1,171 @@
+package cmd
+
+import (
+	"crypto/sha256"
+	"errors"
+	"math/big"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/consensys/gnark-crypto/ecc"
+	"github.com/consensys/gnark/backend"
+	"github.com/consensys/gnark/backend/groth16"
+	groth16_bn254 "github.com/consensys/gnark/backend/groth16/bn254"
+	"github.com/consensys/gnark/backend/plonk"
+	plonk_bn254 "github.com/consensys/gnark/backend/plonk/bn254"
+	"github.com/consensys/gnark/backend/witness"
+	"github.com/consensys/gnark/frontend"
+	"github.com/consensys/gnark/frontend/cs/r1cs"
+	"github.com/consensys/gnark/frontend/cs/scs"
+	"github.com/consensys/gnark/test"
+	"github.com/consensys/gnark/test/unsafekzg"
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/zkCollective/zk-Harness/frameworks/gnark/circuits/toy/rangecheck"
+	"github.com/zkCollective/zk-Harness/frameworks/gnark/util"
+)
+
+// proveSolidity proves the range check circuit with Groth16 over BN254, with a commitment to the values
+// if commit is set, and returns the proof, the public witness and the Solidity verifier of the verifying key
+func proveSolidity(t *testing.T, commit bool) (*groth16_bn254.Proof, witness.Witness, string) {
+	assert := test.NewAssert(t)
+
+	circuit := &rangecheck.RangeCheckCircuit{X: make([]frontend.Variable, 4), Bits: 8, Commit: commit}
+	ccs, err := frontend.Compile(ecc.BN254.ScalarField(), r1cs.NewBuilder, circuit)
+	assert.NoError(err)
+	pk, vk, err := groth16.Setup(ccs)
+	assert.NoError(err)
+
+	w, err := frontend.NewWitness(rangecheck.Assignment(4, 8), ecc.BN254.ScalarField())
+	assert.NoError(err)
+	// the Solidity verifier hashes the commitments to the field with sha256
+	proof, err := groth16.Prove(ccs, pk, w, backend.WithProverHashToFieldFunction(sha256.New()))
+	assert.NoError(err)
+	publicWitness, err := w.Public()
+	assert.NoError(err)
+
+	var sb strings.Builder
+	assert.NoError(vk.(solidityExporter).ExportSolidity(&sb))
+	return proof.(*groth16_bn254.Proof), publicWitness, sb.String()
+}
+
+// verifyProofMethod returns the verifyProof method of the Solidity verifier of gnark, whose arguments
+// hold the commitments and their proof of knowledge in between the proof and the public inputs
+func verifyProofMethod(t *testing.T, nbCommitments int, nbPublic int) abi.Method {
+	inputs := `{"name":"proof","type":"uint256[8]"},`
+	if nbCommitments > 0 {
+		inputs += `{"name":"commitments","type":"uint256[` + strconv.Itoa(2*nbCommitments) + `]"},{"name":"commitmentPok","type":"uint256[2]"},`
+	}
+	inputs += `{"name":"input","type":"uint256[` + strconv.Itoa(nbPublic) + `]"}`
+	parsed, err := abi.JSON(strings.NewReader(`[{"type":"function","name":"verifyProof","stateMutability":"view","inputs":[` + inputs + `],"outputs":[]}]`))
+	if err != nil {
+		t.Fatal(err)
+	}
+	return parsed.Methods["verifyProof"]
+}
+
+func TestGroth16Calldata(t *testing.T) {
+	assert := test.NewAssert(t)
+
+	for _, commit := range []bool{false, true} {
+		proof, publicWitness, _ := proveSolidity(t, commit)
+		nbCommitments := len(proof.Commitments)
+		if commit {
+			assert.Equal(1, nbCommitments, "commitments of the range checks")
+		}
+
+		args, err := groth16Calldata(proof.MarshalSolidity(), publicWitness)(verifyProofMethod(t, nbCommitments, 1))
+		assert.NoError(err)
+
+		// Ar, Bs and Krs, the coordinates of Bs over the quadratic extension with the most significant first
+		assert.Equal([8]*big.Int{
+			proof.Ar.X.BigInt(new(big.Int)), proof.Ar.Y.BigInt(new(big.Int)),
+			proof.Bs.X.A1.BigInt(new(big.Int)), proof.Bs.X.A0.BigInt(new(big.Int)),
+			proof.Bs.Y.A1.BigInt(new(big.Int)), proof.Bs.Y.A0.BigInt(new(big.Int)),
+			proof.Krs.X.BigInt(new(big.Int)), proof.Krs.Y.BigInt(new(big.Int)),
+		}, args[0], "commit=%t", commit)
+		if commit {
+			// the number of commitments on 4 bytes is skipped
+			assert.Equal(4, len(args))
+			assert.Equal([2]*big.Int{proof.Commitments[0].X.BigInt(new(big.Int)), proof.Commitments[0].Y.BigInt(new(big.Int))}, args[1])
+			assert.Equal([2]*big.Int{proof.CommitmentPok.X.BigInt(new(big.Int)), proof.CommitmentPok.Y.BigInt(new(big.Int))}, args[2])
+		} else {
+			assert.Equal(2, len(args))
+		}
+		assert.Equal([1]*big.Int{big.NewInt(0 + 1 + 2 + 3)}, args[len(args)-1], "public sum")
+
+		// the calldata must fill the arguments of the method exactly
+		_, err = groth16Calldata(proof.MarshalSolidity(), publicWitness)(verifyProofMethod(t, nbCommitments, 2))
+		assert.Error(err, "missing public input")
+		if commit {
+			_, err = groth16Calldata(proof.MarshalSolidity(), publicWitness)(verifyProofMethod(t, 0, 1))
+			assert.Error(err, "commitments left")
+		}
+	}
+}
+
+func TestPlonkCalldata(t *testing.T) {
+	assert := test.NewAssert(t)
+
+	circuit := &rangecheck.RangeCheckCircuit{X: make([]frontend.Variable, 4), Bits: 8}
+	ccs, err := frontend.Compile(ecc.BN254.ScalarField(), scs.NewBuilder, circuit)
+	assert.NoError(err)
+	srs, srsLagrange, err := unsafekzg.NewSRS(ccs)
+	assert.NoError(err)
+	pk, vk, err := plonk.Setup(ccs, srs, srsLagrange)
+	assert.NoError(err)
+
+	w, err := frontend.NewWitness(rangecheck.Assignment(4, 8), ecc.BN254.ScalarField())
+	assert.NoError(err)
+	proof, err := plonk.Prove(ccs, pk, w)
+	assert.NoError(err)
+	publicWitness, err := w.Public()
+	assert.NoError(err)
+	assert.NoError(plonk.Verify(proof, vk, publicWitness))
+
+	parsed, err := abi.JSON(strings.NewReader(`[{"type":"function","name":"Verify","stateMutability":"view","inputs":[{"name":"proof","type":"bytes"},{"name":"public_inputs","type":"uint256[]"}],"outputs":[{"name":"success","type":"bool"}]}]`))
+	assert.NoError(err)
+	serialized := proof.(*plonk_bn254.Proof).MarshalSolidity()
+	args, err := plonkCalldata(serialized, publicWitness)(parsed.Methods["Verify"])
+	assert.NoError(err)
+	assert.Equal([]interface{}{serialized, []*big.Int{big.NewInt(0 + 1 + 2 + 3)}}, args)
+
+	// the arguments are packed by the ABI of Verify
+	_, err = parsed.Methods["Verify"].Inputs.Pack(args...)
+	assert.NoError(err)
+
+	// the calldata of a Groth16 verifier is not the serialized proof
+	_, err = plonkCalldata(serialized, publicWitness)(verifyProofMethod(t, 0, 1))
+	assert.Error(err)
+}
+
+// TestGroth16Gas verifies the calldata of groth16Calldata with the exported Solidity verifier on the EVM
+func TestGroth16Gas(t *testing.T) {
+	assert := test.NewAssert(t)
+
+	for _, commit := range []bool{false, true} {
+		proof, publicWitness, verifier := proveSolidity(t, commit)
+
+		path := filepath.Join(t.TempDir(), "verifier.sol")
+		assert.NoError(os.WriteFile(path, []byte(verifier), 0o600))
+		contract, err := util.CompileSolidity(path, "Verifier")
+		if errors.Is(err, util.ErrNoSolc) {
+			t.Skip("solc not found")
+		}
+		assert.NoError(err)
+
+		args, err := groth16Calldata(proof.MarshalSolidity(), publicWitness)(contract.ABI.Methods["verifyProof"])
+		assert.NoError(err)
+		cost, err := util.MeasureGas(contract, "verifyProof", args...)
+		assert.NoError(err, "commit=%t", commit)
+		assert.True(cost.Execution > 0 && cost.Deploy > 0, "gas of %+v", cost)
+
+		// a proof of other public inputs is rejected
+		public := args[len(args)-1].([1]*big.Int)
+		args[len(args)-1] = [1]*big.Int{new(big.Int).Add(public[0], big.NewInt(1))}
+		_, err = util.MeasureGas(contract, "verifyProof", args...)
+		assert.Error(err, "commit=%t", commit)
+	}
+}
@@ -0,0 +1,89 @@
+package cmd
+
+import (
+	"bufio"
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/consensys/gnark-crypto/kzg"
+	"github.com/consensys/gnark/constraint"
+	"github.com/consensys/gnark/logger"
+	"github.com/consensys/gnark/test/unsafekzg"
+	"github.com/spf13/cobra"
+	"github.com/zkCollective/zk-Harness/frameworks/gnark/parser"
+	"github.com/zkCollective/zk-Harness/frameworks/gnark/util"
+)
+
+// srsCmd represents the srs command
+var srsCmd = &cobra.Command{
+	Use:   "srs",
+	Short: "writes a PlonK SRS of --size points, read from --srs or generated, to --outputPath",
+	Run:   runSRS,
+}
+
+func runSRS(cmd *cobra.Command, args []string) {
+	if err := parser.ParseFlagsSRS(cfg); err != nil {
+		fmt.Println("error: ", err.Error())
+		cmd.Help()
+		os.Exit(-1)
+	}
+
+	log := logger.Logger()
+	var (
+		srs kzg.SRS
+		err error
+	)
+	if *cfg.SRS == "none" {
+		log.Info().Msg("Generating an SRS of " + fmt.Sprint(*cfg.CircuitSize) + " points over " + *cfg.Curve)
+		srs, err = util.GenerateSRS(parser.CurveID, *cfg.CircuitSize)
+	} else {
+		log.Info().Msg("Reading an SRS of " + fmt.Sprint(*cfg.CircuitSize) + " points from " + *cfg.SRS)
+		if srs, err = util.ReadSRS(parser.CurveID, *cfg.SRS, *cfg.CircuitSize); err == nil {
+			srs, err = util.TrimSRS(srs, *cfg.CircuitSize)
+		}
+	}
+	assertNoError(err)
+
+	f, err := os.Create(*cfg.OutputPath)
+	assertNoError(err)
+	w := bufio.NewWriter(f)
+	_, err = srs.WriteTo(w)
+	assertNoError(err)
+	assertNoError(w.Flush())
+	assertNoError(f.Close())
+}
+
+// newPlonkSRS returns the canonical and Lagrange SRS of the PlonK setup of the constraint system.
+// The SRS is loaded from the file at srsPath and converted to the Lagrange form or, without file,
+// generated from a known secret. The time of each of these steps is reported to fnWriteSRS, if set.
+func newPlonkSRS(ccs constraint.ConstraintSystem, srsPath string, fnWriteSRS util.PhaseWriteFunction) (kzg.SRS, kzg.SRS) {
+	report := func(phase string, start time.Time) {
+		if fnWriteSRS != nil {
+			fnWriteSRS(phase, time.Since(start), ccs)
+		}
+	}
+
+	start := time.Now()
+	if srsPath == "" || srsPath == "none" {
+		srs, srsLagrange, err := unsafekzg.NewSRS(ccs)
+		assertNoError(err)
+		report("srs_generate", start)
+		return srs, srsLagrange
+	}
+
+	sizeCanonical, sizeLagrange := util.SRSSize(ccs)
+	srs, err := util.ReadSRS(parser.CurveID, srsPath, sizeCanonical)
+	assertNoError(err)
+	report("srs_load", start)
+
+	start = time.Now()
+	srsLagrange, err := util.ToLagrange(srs, sizeLagrange)
+	assertNoError(err)
+	report("srs_convert", start)
+	return srs, srsLagrange
+}
+
+func init() {
+	rootCmd.AddCommand(srsCmd)
+}
@@ -0,0 +1,52 @@
+package cmd
+
+import (
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/consensys/gnark/constraint"
+	"github.com/zkCollective/zk-Harness/frameworks/gnark/parser"
+	"github.com/zkCollective/zk-Harness/frameworks/gnark/util"
+)
+
+// sweepThreads re-runs a benchmark for each thread count of the --threads flag and reports
+// the speedup and parallel efficiency relative to the single thread baseline
+func sweepThreads(backend string, bench func(fnWrite util.WriteFunction)) {
+	var baseline time.Duration
+	saved, err := util.SaveThreads()
+	assertNoError(err)
+	defer func() { assertNoError(saved.Restore()) }()
+
+	for _, threads := range parser.Threads {
+		fmt.Println("BENCHMARK WITH " + strconv.Itoa(threads) + " THREADS")
+		assertNoError(util.SetThreads(threads, parser.CPUSet))
+
+		bench(func(took time.Duration, ccs constraint.ConstraintSystem, proof_size int) {
+			// the first run of the sweep is always the single thread baseline
+			if baseline == 0 {
+				baseline = took
+			}
+			speedup := float64(baseline) / float64(took)
+			bData := util.BenchDataScaling{
+				Framework:     "gnark",
+				Category:      "scaling",
+				Backend:       backend,
+				Curve:         parser.CurveID.String(),
+				Circuit:       *cfg.Circuit,
+				Input:         *cfg.InputPath,
+				Operation:     *cfg.Algo,
+				NbConstraints: ccs.GetNbConstraints(),
+				Threads:       threads,
+				RunTime:       took.Microseconds(),
+				Speedup:       speedup,
+				Efficiency:    speedup / float64(threads),
+				Count:         *cfg.Count,
+			}
+
+			if err := util.WriteData("csv", bData, *cfg.OutputPath); err != nil {
+				panic(err)
+			}
+		})
+	}
+}
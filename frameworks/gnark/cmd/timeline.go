@@ -0,0 +1,32 @@
+package cmd
+
+import (
+	"path/filepath"
+	"runtime"
+	"strconv"
+	"strings"
+
+	"github.com/zkCollective/zk-Harness/frameworks/gnark/parser"
+	"github.com/zkCollective/zk-Harness/frameworks/gnark/util"
+)
+
+// writeMemTimeline writes the samples of each result row to a sidecar CSV next to the results in filename
+func writeMemTimeline(filename string) util.TimelineWriteFunction {
+	return func(operation string, samples []util.MemSample) {
+		if err := util.WriteTimeline(timelinePath(filename, operation), samples); err != nil {
+			panic(err)
+		}
+	}
+}
+
+// timelinePath names the sidecar of a result row after its curve, input, operation and thread count
+func timelinePath(filename string, operation string) string {
+	stem := strings.TrimSuffix(filename, filepath.Ext(filename))
+	input := filepath.Base(*cfg.InputPath)
+	input = strings.TrimSuffix(input, filepath.Ext(input))
+	name := stem + "_" + strings.ToLower(parser.CurveID.String()) + "_" + input + "_" + operation
+	if len(parser.Threads) > 0 {
+		name += "_t" + strconv.Itoa(runtime.GOMAXPROCS(0))
+	}
+	return name + "_timeline.csv"
+}
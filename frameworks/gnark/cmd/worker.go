@@ -0,0 +1,54 @@
+package cmd
+
+import (
+	"context"
+	"os"
+	"os/signal"
+	"time"
+
+	"github.com/consensys/gnark/logger"
+	"github.com/spf13/cobra"
+	"github.com/zkCollective/zk-Harness/frameworks/gnark/service"
+	"github.com/zkCollective/zk-Harness/frameworks/gnark/util"
+)
+
+// workerCmd represents the worker command
+var workerCmd = &cobra.Command{
+	Use:   "worker",
+	Short: "runs the cells of a coordinator one at a time, each in a child process, and pushes back their results",
+	Run:   runWorker,
+}
+
+var (
+	fWorkerCoordinator *string
+	fWorkerDir         *string
+	fWorkerPoll        *time.Duration
+	fWorkerName        *string
+)
+
+func runWorker(cmd *cobra.Command, args []string) {
+	log := logger.Logger()
+
+	path, err := os.Executable()
+	assertNoError(err)
+	host := util.DescribeHost()
+	if *fWorkerName != "" {
+		host.Name = *fWorkerName
+	}
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt)
+	defer stop()
+
+	log.Info().Str("host", host.Name).Str("cpu", host.CPU).Msg("Working for " + *fWorkerCoordinator)
+	w := service.NewWorker(*fWorkerCoordinator, host, service.ExecRunner(path), *fWorkerDir, *fWorkerPoll)
+	w.Run(ctx)
+}
+
+func init() {
+	fWorkerCoordinator = workerCmd.Flags().String("coordinator", "http://localhost:8081", "URL of the coordinator")
+	fWorkerDir = workerCmd.Flags().String("work-dir", "tmp/worker", "directory the cells write their results to before they are pushed")
+	fWorkerPoll = workerCmd.Flags().Duration("poll", 5*time.Second, "interval at which the coordinator is asked for a cell while it has none")
+	fWorkerName = workerCmd.Flags().String("name", "", "name of the host tagging the results, the hostname by default")
+
+	rootCmd.AddCommand(workerCmd)
+}
@@ -0,0 +1,216 @@
+package ec
+
+import (
+	"github.com/consensys/gnark-crypto/ecc"
+	bls12377 "github.com/consensys/gnark-crypto/ecc/bls12-377"
+	bls12377fp "github.com/consensys/gnark-crypto/ecc/bls12-377/fp"
+	bls12377fr "github.com/consensys/gnark-crypto/ecc/bls12-377/fr"
+	bls12377fft "github.com/consensys/gnark-crypto/ecc/bls12-377/fr/fft"
+	bls12378 "github.com/consensys/gnark-crypto/ecc/bls12-378"
+	bls12378fp "github.com/consensys/gnark-crypto/ecc/bls12-378/fp"
+	bls12378fr "github.com/consensys/gnark-crypto/ecc/bls12-378/fr"
+	bls12378fft "github.com/consensys/gnark-crypto/ecc/bls12-378/fr/fft"
+	bls12381 "github.com/consensys/gnark-crypto/ecc/bls12-381"
+	bls12381fp "github.com/consensys/gnark-crypto/ecc/bls12-381/fp"
+	bls12381fr "github.com/consensys/gnark-crypto/ecc/bls12-381/fr"
+	bls12381fft "github.com/consensys/gnark-crypto/ecc/bls12-381/fr/fft"
+	bls24315 "github.com/consensys/gnark-crypto/ecc/bls24-315"
+	bls24315fp "github.com/consensys/gnark-crypto/ecc/bls24-315/fp"
+	bls24315fr "github.com/consensys/gnark-crypto/ecc/bls24-315/fr"
+	bls24315fft "github.com/consensys/gnark-crypto/ecc/bls24-315/fr/fft"
+	bls24317 "github.com/consensys/gnark-crypto/ecc/bls24-317"
+	bls24317fp "github.com/consensys/gnark-crypto/ecc/bls24-317/fp"
+	bls24317fr "github.com/consensys/gnark-crypto/ecc/bls24-317/fr"
+	bls24317fft "github.com/consensys/gnark-crypto/ecc/bls24-317/fr/fft"
+	bn254 "github.com/consensys/gnark-crypto/ecc/bn254"
+	bn254fp "github.com/consensys/gnark-crypto/ecc/bn254/fp"
+	bn254fr "github.com/consensys/gnark-crypto/ecc/bn254/fr"
+	bn254fft "github.com/consensys/gnark-crypto/ecc/bn254/fr/fft"
+	bw6633 "github.com/consensys/gnark-crypto/ecc/bw6-633"
+	bw6633fp "github.com/consensys/gnark-crypto/ecc/bw6-633/fp"
+	bw6633fr "github.com/consensys/gnark-crypto/ecc/bw6-633/fr"
+	bw6633fft "github.com/consensys/gnark-crypto/ecc/bw6-633/fr/fft"
+	bw6756 "github.com/consensys/gnark-crypto/ecc/bw6-756"
+	bw6756fp "github.com/consensys/gnark-crypto/ecc/bw6-756/fp"
+	bw6756fr "github.com/consensys/gnark-crypto/ecc/bw6-756/fr"
+	bw6756fft "github.com/consensys/gnark-crypto/ecc/bw6-756/fr/fft"
+	bw6761 "github.com/consensys/gnark-crypto/ecc/bw6-761"
+	bw6761fp "github.com/consensys/gnark-crypto/ecc/bw6-761/fp"
+	bw6761fr "github.com/consensys/gnark-crypto/ecc/bw6-761/fr"
+	bw6761fft "github.com/consensys/gnark-crypto/ecc/bw6-761/fr/fft"
+)
+
+func init() {
+	BenchCurves = make(map[ecc.ID][]Op)
+	BenchCurves[ecc.BN254] = bn254Ops()
+	BenchCurves[ecc.BLS12_377] = bls12377Ops()
+	BenchCurves[ecc.BLS12_378] = bls12378Ops()
+	BenchCurves[ecc.BLS12_381] = bls12381Ops()
+	BenchCurves[ecc.BLS24_315] = bls24315Ops()
+	BenchCurves[ecc.BLS24_317] = bls24317Ops()
+	BenchCurves[ecc.BW6_633] = bw6633Ops()
+	BenchCurves[ecc.BW6_756] = bw6756Ops()
+	BenchCurves[ecc.BW6_761] = bw6761Ops()
+}
+
+func bn254Ops() []Op {
+	_, _, g1, g2 := bn254.Generators()
+	var ops []Op
+	ops = append(ops, fieldOps[bn254fr.Element]("fr")...)
+	ops = append(ops, fieldOps[bn254fp.Element]("fp")...)
+	ops = append(ops, fftOps[bn254fr.Element]("fr", func(v []bn254fr.Element) func() {
+		domain := bn254fft.NewDomain(uint64(len(v)))
+		return func() { domain.FFT(v, bn254fft.DIF) }
+	}, func(size int) error {
+		_, err := bn254fft.Generator(uint64(size))
+		return err
+	})...)
+	ops = append(ops, groupOps[bn254.G1Jac, bn254.G1Affine, bn254fr.Element]("g1", g1, bn254.BatchScalarMultiplicationG1, bn254.HashToG1)...)
+	ops = append(ops, groupOps[bn254.G2Jac, bn254.G2Affine, bn254fr.Element]("g2", g2, bn254.BatchScalarMultiplicationG2, bn254.HashToG2)...)
+	ops = append(ops, pairingOps(g1, g2, bn254.Pair)...)
+	return ops
+}
+
+func bls12377Ops() []Op {
+	_, _, g1, g2 := bls12377.Generators()
+	var ops []Op
+	ops = append(ops, fieldOps[bls12377fr.Element]("fr")...)
+	ops = append(ops, fieldOps[bls12377fp.Element]("fp")...)
+	ops = append(ops, fftOps[bls12377fr.Element]("fr", func(v []bls12377fr.Element) func() {
+		domain := bls12377fft.NewDomain(uint64(len(v)))
+		return func() { domain.FFT(v, bls12377fft.DIF) }
+	}, func(size int) error {
+		_, err := bls12377fft.Generator(uint64(size))
+		return err
+	})...)
+	ops = append(ops, groupOps[bls12377.G1Jac, bls12377.G1Affine, bls12377fr.Element]("g1", g1, bls12377.BatchScalarMultiplicationG1, bls12377.HashToG1)...)
+	ops = append(ops, groupOps[bls12377.G2Jac, bls12377.G2Affine, bls12377fr.Element]("g2", g2, bls12377.BatchScalarMultiplicationG2, bls12377.HashToG2)...)
+	ops = append(ops, pairingOps(g1, g2, bls12377.Pair)...)
+	return ops
+}
+
+func bls12378Ops() []Op {
+	_, _, g1, g2 := bls12378.Generators()
+	var ops []Op
+	ops = append(ops, fieldOps[bls12378fr.Element]("fr")...)
+	ops = append(ops, fieldOps[bls12378fp.Element]("fp")...)
+	ops = append(ops, fftOps[bls12378fr.Element]("fr", func(v []bls12378fr.Element) func() {
+		domain := bls12378fft.NewDomain(uint64(len(v)))
+		return func() { domain.FFT(v, bls12378fft.DIF) }
+	}, func(size int) error {
+		_, err := bls12378fft.Generator(uint64(size))
+		return err
+	})...)
+	ops = append(ops, groupOps[bls12378.G1Jac, bls12378.G1Affine, bls12378fr.Element]("g1", g1, bls12378.BatchScalarMultiplicationG1, bls12378.HashToG1)...)
+	ops = append(ops, groupOps[bls12378.G2Jac, bls12378.G2Affine, bls12378fr.Element]("g2", g2, bls12378.BatchScalarMultiplicationG2, bls12378.HashToG2)...)
+	ops = append(ops, pairingOps(g1, g2, bls12378.Pair)...)
+	return ops
+}
+
+func bls12381Ops() []Op {
+	_, _, g1, g2 := bls12381.Generators()
+	var ops []Op
+	ops = append(ops, fieldOps[bls12381fr.Element]("fr")...)
+	ops = append(ops, fieldOps[bls12381fp.Element]("fp")...)
+	ops = append(ops, fftOps[bls12381fr.Element]("fr", func(v []bls12381fr.Element) func() {
+		domain := bls12381fft.NewDomain(uint64(len(v)))
+		return func() { domain.FFT(v, bls12381fft.DIF) }
+	}, func(size int) error {
+		_, err := bls12381fft.Generator(uint64(size))
+		return err
+	})...)
+	ops = append(ops, groupOps[bls12381.G1Jac, bls12381.G1Affine, bls12381fr.Element]("g1", g1, bls12381.BatchScalarMultiplicationG1, bls12381.HashToG1)...)
+	ops = append(ops, groupOps[bls12381.G2Jac, bls12381.G2Affine, bls12381fr.Element]("g2", g2, bls12381.BatchScalarMultiplicationG2, bls12381.HashToG2)...)
+	ops = append(ops, pairingOps(g1, g2, bls12381.Pair)...)
+	return ops
+}
+
+func bls24315Ops() []Op {
+	_, _, g1, g2 := bls24315.Generators()
+	var ops []Op
+	ops = append(ops, fieldOps[bls24315fr.Element]("fr")...)
+	ops = append(ops, fieldOps[bls24315fp.Element]("fp")...)
+	ops = append(ops, fftOps[bls24315fr.Element]("fr", func(v []bls24315fr.Element) func() {
+		domain := bls24315fft.NewDomain(uint64(len(v)))
+		return func() { domain.FFT(v, bls24315fft.DIF) }
+	}, func(size int) error {
+		_, err := bls24315fft.Generator(uint64(size))
+		return err
+	})...)
+	ops = append(ops, groupOps[bls24315.G1Jac, bls24315.G1Affine, bls24315fr.Element]("g1", g1, bls24315.BatchScalarMultiplicationG1, bls24315.HashToG1)...)
+	ops = append(ops, groupOps[bls24315.G2Jac, bls24315.G2Affine, bls24315fr.Element]("g2", g2, bls24315.BatchScalarMultiplicationG2, bls24315.HashToG2)...)
+	ops = append(ops, pairingOps(g1, g2, bls24315.Pair)...)
+	return ops
+}
+
+func bls24317Ops() []Op {
+	_, _, g1, g2 := bls24317.Generators()
+	var ops []Op
+	ops = append(ops, fieldOps[bls24317fr.Element]("fr")...)
+	ops = append(ops, fieldOps[bls24317fp.Element]("fp")...)
+	ops = append(ops, fftOps[bls24317fr.Element]("fr", func(v []bls24317fr.Element) func() {
+		domain := bls24317fft.NewDomain(uint64(len(v)))
+		return func() { domain.FFT(v, bls24317fft.DIF) }
+	}, func(size int) error {
+		_, err := bls24317fft.Generator(uint64(size))
+		return err
+	})...)
+	ops = append(ops, groupOps[bls24317.G1Jac, bls24317.G1Affine, bls24317fr.Element]("g1", g1, bls24317.BatchScalarMultiplicationG1, bls24317.HashToG1)...)
+	ops = append(ops, groupOps[bls24317.G2Jac, bls24317.G2Affine, bls24317fr.Element]("g2", g2, bls24317.BatchScalarMultiplicationG2, bls24317.HashToG2)...)
+	ops = append(ops, pairingOps(g1, g2, bls24317.Pair)...)
+	return ops
+}
+
+func bw6633Ops() []Op {
+	_, _, g1, g2 := bw6633.Generators()
+	var ops []Op
+	ops = append(ops, fieldOps[bw6633fr.Element]("fr")...)
+	ops = append(ops, fieldOps[bw6633fp.Element]("fp")...)
+	ops = append(ops, fftOps[bw6633fr.Element]("fr", func(v []bw6633fr.Element) func() {
+		domain := bw6633fft.NewDomain(uint64(len(v)))
+		return func() { domain.FFT(v, bw6633fft.DIF) }
+	}, func(size int) error {
+		_, err := bw6633fft.Generator(uint64(size))
+		return err
+	})...)
+	ops = append(ops, groupOps[bw6633.G1Jac, bw6633.G1Affine, bw6633fr.Element]("g1", g1, bw6633.BatchScalarMultiplicationG1, bw6633.HashToG1)...)
+	ops = append(ops, groupOps[bw6633.G2Jac, bw6633.G2Affine, bw6633fr.Element]("g2", g2, bw6633.BatchScalarMultiplicationG2, bw6633.HashToG2)...)
+	ops = append(ops, pairingOps(g1, g2, bw6633.Pair)...)
+	return ops
+}
+
+func bw6756Ops() []Op {
+	_, _, g1, g2 := bw6756.Generators()
+	var ops []Op
+	ops = append(ops, fieldOps[bw6756fr.Element]("fr")...)
+	ops = append(ops, fieldOps[bw6756fp.Element]("fp")...)
+	ops = append(ops, fftOps[bw6756fr.Element]("fr", func(v []bw6756fr.Element) func() {
+		domain := bw6756fft.NewDomain(uint64(len(v)))
+		return func() { domain.FFT(v, bw6756fft.DIF) }
+	}, func(size int) error {
+		_, err := bw6756fft.Generator(uint64(size))
+		return err
+	})...)
+	ops = append(ops, groupOps[bw6756.G1Jac, bw6756.G1Affine, bw6756fr.Element]("g1", g1, bw6756.BatchScalarMultiplicationG1, bw6756.HashToG1)...)
+	ops = append(ops, groupOps[bw6756.G2Jac, bw6756.G2Affine, bw6756fr.Element]("g2", g2, bw6756.BatchScalarMultiplicationG2, bw6756.HashToG2)...)
+	ops = append(ops, pairingOps(g1, g2, bw6756.Pair)...)
+	return ops
+}
+
+func bw6761Ops() []Op {
+	_, _, g1, g2 := bw6761.Generators()
+	var ops []Op
+	ops = append(ops, fieldOps[bw6761fr.Element]("fr")...)
+	ops = append(ops, fieldOps[bw6761fp.Element]("fp")...)
+	ops = append(ops, fftOps[bw6761fr.Element]("fr", func(v []bw6761fr.Element) func() {
+		domain := bw6761fft.NewDomain(uint64(len(v)))
+		return func() { domain.FFT(v, bw6761fft.DIF) }
+	}, func(size int) error {
+		_, err := bw6761fft.Generator(uint64(size))
+		return err
+	})...)
+	ops = append(ops, groupOps[bw6761.G1Jac, bw6761.G1Affine, bw6761fr.Element]("g1", g1, bw6761.BatchScalarMultiplicationG1, bw6761.HashToG1)...)
+	ops = append(ops, groupOps[bw6761.G2Jac, bw6761.G2Affine, bw6761fr.Element]("g2", g2, bw6761.BatchScalarMultiplicationG2, bw6761.HashToG2)...)
+	ops = append(ops, pairingOps(g1, g2, bw6761.Pair)...)
+	return ops
+}
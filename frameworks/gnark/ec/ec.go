@@ -0,0 +1,402 @@
+/*
+Benchmarking elliptic curve and field operations of gnark-crypto
+*/
+package ec
+
+import (
+	"errors"
+	"math"
+	"math/big"
+	"math/bits"
+	"sort"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/consensys/gnark-crypto/ecc"
+	"github.com/consensys/gnark/logger"
+)
+
+// Op is the benchmark of a curve operation, optionally parameterized by an input size
+type Op struct {
+	Group     string
+	Operation string
+	Sizes     []int
+	// Kernel operations are swept over the sizes given to Run and fitted to an n log n cost model
+	Kernel bool
+	// Supports, when set, returns an error if the operation cannot run at the given size
+	Supports func(size int) error
+	// Bench prepares the inputs of the given size and returns the function to benchmark
+	Bench func(size int) func(b *testing.B)
+}
+
+// Result holds the outcome of an operation benchmark
+type Result struct {
+	Group     string
+	Operation string
+	Size      int
+	N         int
+	NsPerOp   int64
+	// Throughput is the number of elements (points, coefficients or pairs) processed per second
+	Throughput float64
+	// NLogNCost is the fitted cost in ns per n*log2(n) of a kernel sweep
+	NLogNCost float64
+}
+
+var BenchCurves map[ecc.ID][]Op
+
+var (
+	// DefaultSizes are the input sizes of the MSM and FFT benchmarks, 2^8 to 2^20
+	DefaultSizes = powersOfTwo(8, 20)
+	// MultiPairingSizes are the numbers of pairs of the multi-pairing benchmarks
+	MultiPairingSizes = []int{2, 4, 8, 16}
+)
+
+// Run benchmarks the operations of the given curve matching group and operation,
+// "None" matching all of them, and calls fnResult for every result.
+// Kernels are run for the given sizes, or their default sizes if there are none.
+func Run(curve ecc.ID, group string, operation string, sizes []int, fnResult func(Result) error) error {
+	log := logger.Logger()
+
+	ops, ok := BenchCurves[curve]
+	if !ok {
+		return errors.New("curve operations not implemented for " + curve.String())
+	}
+	for _, op := range ops {
+		if group != "None" && group != op.Group {
+			continue
+		}
+		if operation != "None" && operation != op.Operation {
+			continue
+		}
+		opSizes := op.Sizes
+		if op.Kernel && sizes != nil {
+			opSizes = sizes
+		}
+		if opSizes == nil {
+			opSizes = []int{0}
+		}
+
+		// results are written once the sweep is complete, for the cost model to be fitted
+		var results []Result
+		for _, size := range opSizes {
+			if op.Supports != nil {
+				if err := op.Supports(size); err != nil {
+					log.Warn().Err(err).Msg("Skipping " + op.Group + "-" + op.Operation + " of size " + strconv.Itoa(size))
+					continue
+				}
+			}
+			r := testing.Benchmark(op.Bench(size))
+			result := Result{
+				Group:     op.Group,
+				Operation: op.Operation,
+				Size:      size,
+				N:         r.N,
+				NsPerOp:   r.NsPerOp(),
+			}
+			if size > 0 && result.NsPerOp > 0 {
+				result.Throughput = float64(size) * 1e9 / float64(result.NsPerOp)
+			}
+			results = append(results, result)
+		}
+		if op.Kernel {
+			cost := fitNLogN(results)
+			for i := range results {
+				results[i].NLogNCost = cost
+			}
+		}
+		for _, result := range results {
+			if err := fnResult(result); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// Operations returns the sorted names of the operations of the group over every curve, "None"
+// standing for every group
+func Operations(group string) []string {
+	seen := make(map[string]struct{})
+	var operations []string
+	for _, ops := range BenchCurves {
+		for _, op := range ops {
+			if group != "None" && group != op.Group {
+				continue
+			}
+			if _, ok := seen[op.Operation]; !ok {
+				seen[op.Operation] = struct{}{}
+				operations = append(operations, op.Operation)
+			}
+		}
+	}
+	sort.Strings(operations)
+	return operations
+}
+
+// fitNLogN fits the runtimes of a sweep to t(n) = c*n*log2(n) and returns c in ns.
+// The relative error is minimized, such that the small sizes weigh as much as the large ones:
+// c = sum(x/t) / sum(x^2/t^2), with x = n*log2(n).
+func fitNLogN(results []Result) float64 {
+	var num, den float64
+	for _, r := range results {
+		if r.Size < 2 || r.NsPerOp <= 0 {
+			continue
+		}
+		x := float64(r.Size) * math.Log2(float64(r.Size))
+		t := float64(r.NsPerOp)
+		num += x / t
+		den += (x * x) / (t * t)
+	}
+	if den == 0 {
+		return 0
+	}
+	return num / den
+}
+
+// ParseSizes parses a comma separated list of sizes, each being a number, a power of
+// two such as 2^10, or a range of powers of two such as 2^10..2^24
+func ParseSizes(s string) ([]int, error) {
+	var sizes []int
+	for _, part := range strings.Split(s, ",") {
+		from, to, isRange := strings.Cut(strings.TrimSpace(part), "..")
+		if !isRange {
+			n, _, err := parseSize(from)
+			if err != nil {
+				return nil, err
+			}
+			sizes = append(sizes, n)
+			continue
+		}
+		_, logFrom, err := parseSize(from)
+		if err != nil {
+			return nil, err
+		}
+		_, logTo, err := parseSize(to)
+		if err != nil {
+			return nil, err
+		}
+		if logFrom < 0 || logTo < 0 {
+			return nil, errors.New("range bounds must be powers of two: " + part)
+		}
+		if logFrom > logTo {
+			return nil, errors.New("invalid range of sizes: " + part)
+		}
+		sizes = append(sizes, powersOfTwo(logFrom, logTo)...)
+	}
+	return sizes, nil
+}
+
+// parseSize parses a size, returning its base 2 logarithm if it is a power of two, -1 otherwise
+func parseSize(s string) (int, int, error) {
+	if strings.HasPrefix(s, "2^") {
+		e, err := strconv.Atoi(strings.TrimPrefix(s, "2^"))
+		if err != nil || e < 0 || e > 40 {
+			return 0, 0, errors.New("invalid size: " + s)
+		}
+		return 1 << e, e, nil
+	}
+	n, err := strconv.Atoi(s)
+	if err != nil || n <= 0 {
+		return 0, 0, errors.New("invalid size: " + s)
+	}
+	if n&(n-1) == 0 {
+		return n, bits.TrailingZeros(uint(n)), nil
+	}
+	return n, -1, nil
+}
+
+func powersOfTwo(from, to int) []int {
+	sizes := make([]int, 0, to-from+1)
+	for i := from; i <= to; i++ {
+		sizes = append(sizes, 1<<i)
+	}
+	return sizes
+}
+
+// field is implemented by the pointer of every gnark-crypto field element
+type field[T any] interface {
+	*T
+	Add(x, y *T) *T
+	Mul(x, y *T) *T
+	Inverse(x *T) *T
+	Sqrt(x *T) *T
+	SetRandom() (*T, error)
+	BigInt(res *big.Int) *big.Int
+}
+
+// jacobian is implemented by the pointer of every gnark-crypto point in Jacobian coordinates
+type jacobian[J, A, S any] interface {
+	*J
+	AddAssign(a *J) *J
+	DoubleAssign() *J
+	ScalarMultiplication(a *J, s *big.Int) *J
+	FromAffine(a *A) *J
+	MultiExp(points []A, scalars []S, config ecc.MultiExpConfig) (*J, error)
+}
+
+func randomElement[T any, PT field[T]]() T {
+	var x T
+	if _, err := PT(&x).SetRandom(); err != nil {
+		panic(err)
+	}
+	return x
+}
+
+func randomVector[T any, PT field[T]](size int) []T {
+	v := make([]T, size)
+	for i := range v {
+		v[i] = randomElement[T, PT]()
+	}
+	return v
+}
+
+// fieldOps benchmarks the arithmetic of a field
+func fieldOps[T any, PT field[T]](group string) []Op {
+	binary := func(fn func(z, x, y *T)) func(int) func(b *testing.B) {
+		return func(int) func(b *testing.B) {
+			x, y := randomElement[T, PT](), randomElement[T, PT]()
+			return func(b *testing.B) {
+				for i := 0; i < b.N; i++ {
+					fn(&x, &x, &y)
+				}
+			}
+		}
+	}
+	unary := func(fn func(z, x *T)) func(int) func(b *testing.B) {
+		return func(int) func(b *testing.B) {
+			x := randomElement[T, PT]()
+			// square x, such that the square root always exists
+			PT(&x).Mul(&x, &x)
+			var z T
+			return func(b *testing.B) {
+				for i := 0; i < b.N; i++ {
+					fn(&z, &x)
+				}
+			}
+		}
+	}
+
+	return []Op{
+		{Group: group, Operation: "add", Bench: binary(func(z, x, y *T) { PT(z).Add(x, y) })},
+		{Group: group, Operation: "mul", Bench: binary(func(z, x, y *T) { PT(z).Mul(x, y) })},
+		{Group: group, Operation: "inverse", Bench: unary(func(z, x *T) { PT(z).Inverse(x) })},
+		{Group: group, Operation: "sqrt", Bench: unary(func(z, x *T) { PT(z).Sqrt(x) })},
+	}
+}
+
+// groupOps benchmarks the arithmetic of an elliptic curve group with scalars S
+func groupOps[J, A, S any, PJ jacobian[J, A, S], PS field[S]](
+	group string,
+	generator A,
+	batchScalarMultiplication func(base *A, scalars []S) []A,
+	hashToCurve func(msg, dst []byte) (A, error)) []Op {
+
+	randomPoint := func() J {
+		var p J
+		s := randomElement[S, PS]()
+		var g J
+		PJ(&g).FromAffine(&generator)
+		PJ(&p).ScalarMultiplication(&g, PS(&s).BigInt(new(big.Int)))
+		return p
+	}
+
+	// the MSM bases are extended as the benchmarked sizes grow, such that each is computed once
+	var bases []A
+	msmBases := func(size int) []A {
+		if len(bases) < size {
+			bases = append(bases, batchScalarMultiplication(&generator, randomVector[S, PS](size-len(bases)))...)
+		}
+		return bases[:size]
+	}
+
+	return []Op{
+		{Group: group, Operation: "add", Bench: func(int) func(b *testing.B) {
+			p, q := randomPoint(), randomPoint()
+			return func(b *testing.B) {
+				for i := 0; i < b.N; i++ {
+					PJ(&p).AddAssign(&q)
+				}
+			}
+		}},
+		{Group: group, Operation: "double", Bench: func(int) func(b *testing.B) {
+			p := randomPoint()
+			return func(b *testing.B) {
+				for i := 0; i < b.N; i++ {
+					PJ(&p).DoubleAssign()
+				}
+			}
+		}},
+		{Group: group, Operation: "scalar_mul", Bench: func(int) func(b *testing.B) {
+			p := randomPoint()
+			s := randomElement[S, PS]()
+			scalar := PS(&s).BigInt(new(big.Int))
+			var r J
+			return func(b *testing.B) {
+				for i := 0; i < b.N; i++ {
+					PJ(&r).ScalarMultiplication(&p, scalar)
+				}
+			}
+		}},
+		{Group: group, Operation: "msm", Sizes: DefaultSizes, Kernel: true, Bench: func(size int) func(b *testing.B) {
+			points := msmBases(size)
+			scalars := randomVector[S, PS](size)
+			var r J
+			return func(b *testing.B) {
+				for i := 0; i < b.N; i++ {
+					if _, err := PJ(&r).MultiExp(points, scalars, ecc.MultiExpConfig{}); err != nil {
+						b.Fatal(err)
+					}
+				}
+			}
+		}},
+		{Group: group, Operation: "hash_to_curve", Bench: func(int) func(b *testing.B) {
+			msg := []byte("zk-Harness hash to curve benchmark")
+			dst := []byte("ZKHARNESS-V01-CS01-with-expander")
+			return func(b *testing.B) {
+				for i := 0; i < b.N; i++ {
+					if _, err := hashToCurve(msg, dst); err != nil {
+						b.Fatal(err)
+					}
+				}
+			}
+		}},
+	}
+}
+
+// fftOps benchmarks the FFT over the scalar field S, newFFT returning the transform of v
+// over a domain of its size, and supports failing for sizes above the 2-adicity of S
+func fftOps[S any, PS field[S]](group string, newFFT func(v []S) func(), supports func(size int) error) []Op {
+	return []Op{
+		{Group: group, Operation: "fft", Sizes: DefaultSizes, Kernel: true, Supports: supports, Bench: func(size int) func(b *testing.B) {
+			fft := newFFT(randomVector[S, PS](size))
+			return func(b *testing.B) {
+				for i := 0; i < b.N; i++ {
+					fft()
+				}
+			}
+		}},
+	}
+}
+
+// pairingOps benchmarks the pairing and the multi-pairing e(P1, Q1)*...*e(Pn, Qn)
+func pairingOps[G1, G2, GT any](g1 G1, g2 G2, pair func(P []G1, Q []G2) (GT, error)) []Op {
+	bench := func(size int) func(b *testing.B) {
+		P := make([]G1, size)
+		Q := make([]G2, size)
+		for i := range P {
+			P[i], Q[i] = g1, g2
+		}
+		return func(b *testing.B) {
+			for i := 0; i < b.N; i++ {
+				if _, err := pair(P, Q); err != nil {
+					b.Fatal(err)
+				}
+			}
+		}
+	}
+	return []Op{
+		{Group: "gt", Operation: "pairing", Bench: func(int) func(b *testing.B) { return bench(1) }},
+		{Group: "gt", Operation: "multi_pairing", Sizes: MultiPairingSizes, Bench: bench},
+	}
+}
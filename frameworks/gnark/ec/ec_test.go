@@ -0,0 +1,92 @@
+package ec
+
+import (
+	"math"
+	"testing"
+
+	"github.com/consensys/gnark/test"
+)
+
+func TestParseSizes(t *testing.T) {
+	assert := test.NewAssert(t)
+
+	for _, tc := range []struct {
+		sizes    string
+		expected []int
+	}{
+		{"1000", []int{1000}},
+		{"2^10", []int{1 << 10}},
+		{"2^10..2^12", []int{1 << 10, 1 << 11, 1 << 12}},
+		{"1024..4096", []int{1 << 10, 1 << 11, 1 << 12}},
+		{"2^4..2^4", []int{1 << 4}},
+		{"2^8..2^9, 100,2^2", []int{1 << 8, 1 << 9, 100, 1 << 2}},
+	} {
+		sizes, err := ParseSizes(tc.sizes)
+		assert.NoError(err, tc.sizes)
+		assert.Equal(tc.expected, sizes, tc.sizes)
+	}
+
+	for _, sizes := range []string{
+		"",
+		"abc",
+		"0",
+		"-4",
+		"2^x",
+		"2^41",
+		"2^10,",
+		"2^12..2^10",
+		"100..2^10",
+		"2^10..",
+		"2^10..2^12..2^14",
+	} {
+		_, err := ParseSizes(sizes)
+		assert.Error(err, sizes)
+	}
+}
+
+func TestFitNLogN(t *testing.T) {
+	assert := test.NewAssert(t)
+
+	const cost = 3.5
+	sweep := func(noise ...float64) []Result {
+		var results []Result
+		for i, size := range powersOfTwo(8, 20) {
+			t := cost * float64(size) * math.Log2(float64(size))
+			if i < len(noise) {
+				t *= noise[i]
+			}
+			results = append(results, Result{Size: size, NsPerOp: int64(math.Round(t))})
+		}
+		return results
+	}
+
+	// exact n log n runtimes, and the sizes that cannot be fitted, which are ignored
+	results := append(sweep(), Result{Size: 0, NsPerOp: 100}, Result{Size: 1, NsPerOp: 100}, Result{Size: 1 << 10})
+	assert.InDelta(cost, fitNLogN(results), 1e-6)
+
+	// symmetric relative errors average out
+	assert.InDelta(cost, fitNLogN(sweep(1.1, 0.9, 1.1, 0.9)), 0.05)
+
+	// linear runtimes are under-fitted at the large sizes
+	var linear []Result
+	for _, size := range powersOfTwo(8, 20) {
+		linear = append(linear, Result{Size: size, NsPerOp: int64(size) * 10})
+	}
+	c := fitNLogN(linear)
+	assert.True(c < 10.0/8 && c > 10.0/20, "cost %f of a linear sweep", c)
+
+	assert.Equal(0.0, fitNLogN(nil))
+	assert.Equal(0.0, fitNLogN([]Result{{Size: 1, NsPerOp: 10}}))
+}
+
+func TestOperations(t *testing.T) {
+	assert := test.NewAssert(t)
+
+	assert.Equal([]string{"add", "fft", "inverse", "mul", "sqrt"}, Operations("fr"))
+	assert.Equal([]string{"multi_pairing", "pairing"}, Operations("gt"))
+	for _, operation := range []string{"msm", "fft", "hash_to_curve", "pairing", "scalar_mul"} {
+		assert.Contains(Operations("None"), operation)
+	}
+	assert.NotContains(Operations("g1"), "pairing")
+	assert.Empty(Operations("g3"))
+}
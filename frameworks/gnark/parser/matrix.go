@@ -0,0 +1,184 @@
+package parser
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+	"sort"
+	"strings"
+
+	"github.com/consensys/gnark-crypto/ecc"
+	"github.com/zkCollective/zk-Harness/frameworks/gnark/circuits"
+)
+
+// Backends are the backends of the groth16 and plonk commands, in the order of the matrix
+var Backends = []string{"groth16", "plonk"}
+
+// Algos are the algorithms benchmarked by the groth16 and plonk commands
+var Algos = []string{"compile", "setup", "witness", "prove", "verify", "serialize", "export-solidity"}
+
+// backendCurves maps the backends to the curves gnark implements their constraint systems over,
+// compiling over the other curves of ecc.Implemented panicking
+var backendCurves = map[string][]ecc.ID{
+	"groth16": {ecc.BN254, ecc.BLS12_377, ecc.BLS12_381, ecc.BW6_761, ecc.BLS24_315, ecc.BLS24_317, ecc.BW6_633},
+	"plonk":   {ecc.BN254, ecc.BLS12_377, ecc.BLS12_381, ecc.BW6_761, ecc.BLS24_315, ecc.BLS24_317, ecc.BW6_633},
+}
+
+// backendCommitments are the backends proving the commitments of api.Commit, with BSB22 commitments
+var backendCommitments = map[string]bool{
+	"groth16": true,
+	"plonk":   true,
+}
+
+// algoCurves restricts the algorithms implemented over some of the curves only
+var algoCurves = map[string][]ecc.ID{
+	"export-solidity": {ecc.BN254},
+}
+
+// Support returns nil if the groth16 and plonk commands benchmark algo of the circuit with the backend
+// over the curve, or the reason why they do not. An empty algo checks the circuit, backend and curve only.
+func Support(circuit string, backend string, curveID ecc.ID, algo string) error {
+	if _, ok := circuits.BenchCircuits[circuit]; !ok {
+		return errors.New("unknown circuit")
+	}
+	curves, ok := backendCurves[backend]
+	if !ok {
+		return errors.New("invalid backend, must be groth16 or plonk")
+	}
+	if !slices.Contains(curves, curveID) {
+		return fmt.Errorf("gnark implements no backend over %s", curveName(curveID))
+	}
+
+	capability := circuits.Capabilities[circuit]
+	if capability.Command != "" {
+		return fmt.Errorf("%s is built around inner proofs by the %s command", circuit, capability.Command)
+	}
+	if len(capability.Curves) > 0 && !slices.Contains(capability.Curves, curveID) {
+		return fmt.Errorf("the %s witness is not implemented over %s", circuit, curveName(curveID))
+	}
+	if capability.Commitments && !backendCommitments[backend] {
+		return fmt.Errorf("%s does not prove the commitments of %s", backend, circuit)
+	}
+
+	if curves, ok := algoCurves[algo]; ok && !slices.Contains(curves, curveID) {
+		names := make([]string, len(curves))
+		for i, id := range curves {
+			names[i] = curveName(id)
+		}
+		return fmt.Errorf("%s is only implemented over %s", algo, strings.Join(names, ", "))
+	}
+	return nil
+}
+
+// twoChains maps the outer curves of the 2-chains to the inner curve they verify natively
+var twoChains = map[ecc.ID]ecc.ID{
+	ecc.BW6_761: ecc.BLS12_377,
+	ecc.BW6_633: ecc.BLS24_315,
+}
+
+// emulatedInnerCurves maps the outer curves of the emulated recursion to the inner curves
+// it verifies, the first being the default
+var emulatedInnerCurves = map[ecc.ID][]ecc.ID{
+	ecc.BN254: {ecc.BN254, ecc.BLS12_381},
+}
+
+// chainCurves maps the outer curves to the curve of the following levels of a recursion chain,
+// which verify the proof of the previous level with the emulated verifier
+var chainCurves = map[ecc.ID]ecc.ID{
+	ecc.BW6_761: ecc.BN254,
+	ecc.BN254:   ecc.BN254,
+}
+
+// InnerCurves returns the curves of the inner proofs verified over the outer curve, the first being
+// the default, and whether they are verified with the emulated verifier
+func InnerCurves(outerCurveID ecc.ID) ([]ecc.ID, bool, error) {
+	if inner, ok := twoChains[outerCurveID]; ok {
+		return []ecc.ID{inner}, false, nil
+	}
+	if inner, ok := emulatedInnerCurves[outerCurveID]; ok {
+		return inner, true, nil
+	}
+	return nil, false, errors.New("recursion not implemented over the curve, must be bw6_761, bw6_633 or bn254")
+}
+
+// RecursionSupport returns nil if the recursion and aggregate commands verify the proofs of the circuit
+// with the inner backend over the inner curve in a circuit over the outer curve, or the reason why they do not
+func RecursionSupport(circuit string, innerBackend string, outerCurveID ecc.ID, innerCurveID ecc.ID) error {
+	innerCurves, _, err := InnerCurves(outerCurveID)
+	if err != nil {
+		return err
+	}
+	if !slices.Contains(innerCurves, innerCurveID) {
+		return fmt.Errorf("%s proofs are not verified over %s", curveName(innerCurveID), curveName(outerCurveID))
+	}
+	if err := Support(circuit, innerBackend, innerCurveID, "prove"); err != nil {
+		return errors.New("inner circuit: " + err.Error())
+	}
+	return nil
+}
+
+// Cell is the support of a circuit with a backend over a curve, Err being the reason if unsupported.
+// The cells of the recursion verify the proofs over the Inner curve, ecc.UNKNOWN otherwise.
+type Cell struct {
+	Circuit string
+	Backend string
+	Curve   ecc.ID
+	Inner   ecc.ID
+	Err     error
+}
+
+// Column is the name of the curve of the cell, preceded by the inner curve for the recursion
+func (c Cell) Column() string {
+	if c.Inner == ecc.UNKNOWN {
+		return curveName(c.Curve)
+	}
+	return curveName(c.Inner) + "/" + curveName(c.Curve)
+}
+
+// Matrix returns the support of algo for every registered circuit, backend and implemented curve,
+// ordered by circuit name, backend and curve
+func Matrix(algo string) []Cell {
+	var cells []Cell
+	for _, name := range circuitNames() {
+		for _, backend := range Backends {
+			for _, id := range ecc.Implemented() {
+				cells = append(cells, Cell{Circuit: name, Backend: backend, Curve: id, Err: Support(name, backend, id, algo)})
+			}
+		}
+	}
+	return cells
+}
+
+// RecursionMatrix returns the support of the recursion for every registered circuit, inner backend and
+// pair of inner and outer curves, ordered by circuit name, inner backend, outer curve and inner curve
+func RecursionMatrix() []Cell {
+	var cells []Cell
+	for _, name := range circuitNames() {
+		for _, backend := range Backends {
+			for _, outer := range ecc.Implemented() {
+				innerCurves, _, err := InnerCurves(outer)
+				if err != nil {
+					continue
+				}
+				for _, inner := range innerCurves {
+					cells = append(cells, Cell{Circuit: name, Backend: backend, Curve: outer, Inner: inner, Err: RecursionSupport(name, backend, outer, inner)})
+				}
+			}
+		}
+	}
+	return cells
+}
+
+// circuitNames returns the names of the registered circuits, sorted
+func circuitNames() []string {
+	names := make([]string, 0, len(circuits.BenchCircuits))
+	for name := range circuits.BenchCircuits {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+func curveName(id ecc.ID) string {
+	return strings.ToLower(id.String())
+}
@@ -0,0 +1,138 @@
+package parser
+
+import (
+	"testing"
+
+	"github.com/consensys/gnark-crypto/ecc"
+	"github.com/consensys/gnark/test"
+	"github.com/zkCollective/zk-Harness/frameworks/gnark/circuits"
+)
+
+func TestSupport(t *testing.T) {
+	assert := test.NewAssert(t)
+
+	for _, tc := range []struct {
+		circuit string
+		backend string
+		curve   ecc.ID
+		algo    string
+		err     string
+	}{
+		{"cubic", "groth16", ecc.BN254, "prove", ""},
+		{"cubic", "plonk", ecc.BLS24_317, "", ""},
+		{"sha2", "plonk", ecc.BW6_761, "verify", ""},
+		{"rangecheck_commit", "groth16", ecc.BLS12_381, "prove", ""},
+		{"mimc", "groth16", ecc.BW6_633, "prove", ""},
+		{"mimc", "groth16", ecc.BLS24_317, "prove", "the mimc witness is not implemented over bls24_317"},
+		{"cubic", "groth16", ecc.SECP256K1, "prove", "gnark implements no backend over secp256k1"},
+		{"cubic", "plonkFRI", ecc.BN254, "prove", "invalid backend, must be groth16 or plonk"},
+		{"unknown", "groth16", ecc.BN254, "prove", "unknown circuit"},
+		{"plonk_bn254", "groth16", ecc.BN254, "prove", "plonk_bn254 is built around inner proofs by the recursion command"},
+		{"groth16_bls12377_aggregate", "plonk", ecc.BW6_761, "prove", "groth16_bls12377_aggregate is built around inner proofs by the aggregate command"},
+		{"cubic", "groth16", ecc.BN254, "export-solidity", ""},
+		{"cubic", "plonk", ecc.BLS12_381, "export-solidity", "export-solidity is only implemented over bn254"},
+	} {
+		err := Support(tc.circuit, tc.backend, tc.curve, tc.algo)
+		if tc.err == "" {
+			assert.NoError(err, tc)
+		} else {
+			assert.EqualError(err, tc.err, tc)
+		}
+	}
+}
+
+func TestSupportCommitments(t *testing.T) {
+	assert := test.NewAssert(t)
+
+	// a backend without commitments only proves the circuits not calling api.Commit
+	backendCurves["nocommit"] = backendCurves["groth16"]
+	backendCommitments["nocommit"] = false
+	defer func() {
+		delete(backendCurves, "nocommit")
+		delete(backendCommitments, "nocommit")
+	}()
+
+	for circuit, capability := range circuits.Capabilities {
+		if capability.Command != "" {
+			continue
+		}
+		err := Support(circuit, "nocommit", ecc.BN254, "prove")
+		if capability.Commitments {
+			assert.EqualError(err, "nocommit does not prove the commitments of "+circuit, circuit)
+		} else {
+			assert.NoError(err, circuit)
+		}
+	}
+	for _, circuit := range []string{"rangecheck_commit", "sha2", "emulate"} {
+		assert.True(circuits.Capabilities[circuit].Commitments, circuit)
+	}
+	assert.NoError(Support("rangecheck", "nocommit", ecc.BN254, "prove"))
+}
+
+func TestRecursionSupport(t *testing.T) {
+	assert := test.NewAssert(t)
+
+	for _, tc := range []struct {
+		circuit string
+		backend string
+		outer   ecc.ID
+		inner   ecc.ID
+		err     string
+	}{
+		{"mimc", "groth16", ecc.BW6_761, ecc.BLS12_377, ""},
+		{"sha2", "plonk", ecc.BW6_633, ecc.BLS24_315, ""},
+		{"cubic", "plonk", ecc.BN254, ecc.BN254, ""},
+		{"cubic", "groth16", ecc.BN254, ecc.BLS12_381, ""},
+		{"cubic", "groth16", ecc.BW6_761, ecc.BLS12_381, "bls12_381 proofs are not verified over bw6_761"},
+		{"cubic", "groth16", ecc.BN254, ecc.BW6_761, "bw6_761 proofs are not verified over bn254"},
+		{"cubic", "groth16", ecc.BLS12_381, ecc.BLS12_381, "recursion not implemented over the curve, must be bw6_761, bw6_633 or bn254"},
+		{"plonk_bn254", "plonk", ecc.BN254, ecc.BN254, "inner circuit: plonk_bn254 is built around inner proofs by the recursion command"},
+		{"cubic", "plonkFRI", ecc.BW6_761, ecc.BLS12_377, "inner circuit: invalid backend, must be groth16 or plonk"},
+	} {
+		err := RecursionSupport(tc.circuit, tc.backend, tc.outer, tc.inner)
+		if tc.err == "" {
+			assert.NoError(err, tc)
+		} else {
+			assert.EqualError(err, tc.err, tc)
+		}
+	}
+
+	inner, emulated, err := InnerCurves(ecc.BN254)
+	assert.NoError(err)
+	assert.True(emulated)
+	assert.Equal([]ecc.ID{ecc.BN254, ecc.BLS12_381}, inner, "bn254 being the default")
+	inner, emulated, err = InnerCurves(ecc.BW6_761)
+	assert.NoError(err)
+	assert.False(emulated)
+	assert.Equal([]ecc.ID{ecc.BLS12_377}, inner)
+}
+
+func TestMatrix(t *testing.T) {
+	assert := test.NewAssert(t)
+
+	cells := Matrix("export-solidity")
+	curves := ecc.Implemented()
+	assert.Equal(len(circuits.BenchCircuits)*len(Backends)*len(curves), len(cells))
+	for i, c := range cells {
+		assert.Equal(curves[i%len(curves)], c.Curve, "the curves of a row in order")
+		assert.Equal(ecc.UNKNOWN, c.Inner)
+		assert.Equal(Support(c.Circuit, c.Backend, c.Curve, "export-solidity"), c.Err)
+		if i > 0 && c.Curve == curves[0] {
+			prev := cells[i-1]
+			assert.True(prev.Circuit < c.Circuit || (prev.Circuit == c.Circuit && prev.Backend == "groth16" && c.Backend == "plonk"), "rows sorted by circuit and backend")
+		}
+	}
+	assert.Contains(cells, Cell{Circuit: "cubic", Backend: "plonk", Curve: ecc.BN254})
+	assert.Equal("bn254", cells[0].Column())
+
+	cells = RecursionMatrix()
+	var columns []string
+	for _, c := range cells[:4] {
+		columns = append(columns, c.Column())
+	}
+	assert.Equal([]string{"bn254/bn254", "bls12_381/bn254", "bls12_377/bw6_761", "bls24_315/bw6_633"}, columns)
+	assert.Equal(len(circuits.BenchCircuits)*len(Backends)*len(columns), len(cells))
+	for _, c := range cells {
+		assert.Equal(RecursionSupport(c.Circuit, c.Backend, c.Curve, c.Inner), c.Err)
+	}
+}
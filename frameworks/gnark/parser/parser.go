@@ -2,11 +2,14 @@ package parser
 
 import (
 	"errors"
+	"slices"
 	"strings"
+	"time"
 
 	"github.com/consensys/gnark-crypto/ecc"
 	"github.com/pkg/profile"
 	"github.com/zkCollective/zk-Harness/frameworks/gnark/circuits"
+	"github.com/zkCollective/zk-Harness/frameworks/gnark/util"
 )
 
 type Config struct {
@@ -18,8 +21,19 @@ type Config struct {
 	Curve        *string
 	InputPath    *string
 	Operation    *string
+	InnerCurve   *string
+	InnerBackend *string
 	OuterBackend *string
+	Backend      *string
+	Depth        *int
 	OutputPath   *string
+	Phases       *bool
+	Threads      *string
+	CPUSet       *string
+	MemTimeline  *time.Duration
+	SRS          *string
+	DB           *string
+	RunID        *string
 }
 
 func NewConfig() *Config {
@@ -32,16 +46,32 @@ func NewConfig() *Config {
 		Curve:        new(string),
 		InputPath:    new(string),
 		Operation:    new(string),
+		InnerCurve:   new(string),
+		InnerBackend: new(string),
 		OuterBackend: new(string),
+		Backend:      new(string),
+		Depth:        new(int),
 		OutputPath:   new(string),
+		Phases:       new(bool),
+		Threads:      new(string),
+		CPUSet:       new(string),
+		DB:           new(string),
+		RunID:        new(string),
+		MemTimeline:  new(time.Duration),
+		SRS:          new(string),
 	}
 }
 
 var (
 	InnerCurveID ecc.ID
 	CurveID      ecc.ID
+	Emulated     bool
+	ChainCurveID ecc.ID
+	Curves       []ecc.ID
 	P            func(p *profile.Profile)
 	C            circuits.BenchCircuit
+	Threads      []int
+	CPUSet       []int
 )
 
 var config Config
@@ -55,21 +85,13 @@ func ParseFlags(config *Config) error {
 	}
 
 	switch *config.Algo {
-	case "compile", "setup", "witness", "prove", "verify":
+	case "compile", "setup", "witness", "prove", "verify", "serialize", "export-solidity":
 	default:
 		return errors.New("invalid algo")
 	}
 
-	switch *config.Profile {
-	case "none":
-	case "trace":
-		P = profile.TraceProfile
-	case "cpu":
-		P = profile.CPUProfile
-	case "mem":
-		P = profile.MemProfile
-	default:
-		return errors.New("invalid profile")
+	if err := parseProfile(config); err != nil {
+		return err
 	}
 
 	curves := ecc.Implemented()
@@ -88,6 +110,56 @@ func ParseFlags(config *Config) error {
 		return errors.New("unknown circuit")
 	}
 
+	// the backend is set by the commands benchmarking a circuit of the registry directly
+	if *config.Backend != "" {
+		if err := Support(*config.Circuit, *config.Backend, CurveID, *config.Algo); err != nil {
+			return err
+		}
+	}
+
+	if *config.Threads != "none" {
+		var err error
+		if Threads, err = util.ParseThreads(*config.Threads); err != nil {
+			return err
+		}
+		if *config.Phases {
+			return errors.New("phases cannot be combined with a thread sweep")
+		}
+		if *config.Algo == "serialize" || *config.Algo == "export-solidity" {
+			return errors.New(*config.Algo + " cannot be combined with a thread sweep")
+		}
+	}
+	if *config.MemTimeline < 0 {
+		return errors.New("memory timeline interval must be >= 0")
+	}
+	if *config.CPUSet != "none" {
+		var err error
+		if CPUSet, err = util.ParseCPUSet(*config.CPUSet); err != nil {
+			return err
+		}
+		for _, t := range Threads {
+			if t > len(CPUSet) {
+				return errors.New("cpu set has less CPUs than threads")
+			}
+		}
+	}
+
+	return nil
+}
+
+// parseProfile sets the profile of the timed regions
+func parseProfile(config *Config) error {
+	switch *config.Profile {
+	case "none":
+	case "trace":
+		P = profile.TraceProfile
+	case "cpu":
+		P = profile.CPUProfile
+	case "mem":
+		P = profile.MemProfile
+	default:
+		return errors.New("invalid profile")
+	}
 	return nil
 }
 
@@ -116,6 +188,66 @@ func ParseFlagsMemory(config *Config) error {
 		return errors.New("unknown circuit")
 	}
 
+	return Support(*config.Circuit, *config.Backend, CurveID, "")
+}
+
+// ParseFlagsRecursion parses the flags of the recursion benchmarks and sets the inner curve,
+// which is fixed on the 2-chains and chosen with --innerCurve for the emulated recursion
+func ParseFlagsRecursion(config *Config) error {
+	if err := ParseFlags(config); err != nil {
+		return err
+	}
+
+	if *config.Algo == "serialize" {
+		return errors.New("serialization is benchmarked by the groth16 and plonk commands")
+	}
+	if *config.Algo == "export-solidity" {
+		return errors.New("the Solidity verifier is exported by the groth16 and plonk commands")
+	}
+
+	for _, backend := range []string{*config.InnerBackend, *config.OuterBackend} {
+		switch backend {
+		case "groth16", "plonk":
+		default:
+			return errors.New("invalid recursion backend, must be groth16 or plonk")
+		}
+	}
+
+	innerCurves, emulated, err := InnerCurves(CurveID)
+	if err != nil {
+		return err
+	}
+	Emulated = emulated
+
+	InnerCurveID = innerCurves[0]
+	if *config.InnerCurve != "none" {
+		InnerCurveID = ecc.UNKNOWN
+		for _, id := range innerCurves {
+			if *config.InnerCurve == strings.ToLower(id.String()) {
+				InnerCurveID = id
+			}
+		}
+		if InnerCurveID == ecc.UNKNOWN {
+			return errors.New("invalid inner curve for the outer curve")
+		}
+	}
+	if err := RecursionSupport(*config.Circuit, *config.InnerBackend, CurveID, InnerCurveID); err != nil {
+		return err
+	}
+
+	if *config.Depth <= 0 {
+		return errors.New("recursion depth must be >= 1")
+	}
+	if *config.Depth > 1 {
+		var ok bool
+		if ChainCurveID, ok = chainCurves[CurveID]; !ok {
+			return errors.New("recursion chain not implemented over the curve, must be bw6_761 or bn254")
+		}
+		if *config.Algo != "prove" {
+			return errors.New("recursion chain only benchmarks prove")
+		}
+	}
+
 	return nil
 }
 
@@ -124,3 +256,69 @@ func AssertNoError(err error) {
 		panic(err)
 	}
 }
+
+// ParseFlagsEC parses the flags of the curve operation benchmarks, where
+// the curve "all" selects every implemented curve
+func ParseFlagsEC(config *Config) error {
+	if *config.Count <= 0 {
+		return errors.New("bench count must be >= 0")
+	}
+
+	Curves = nil
+	for _, id := range ecc.Implemented() {
+		if *config.Curve == "all" || *config.Curve == strings.ToLower(id.String()) {
+			Curves = append(Curves, id)
+		}
+	}
+	if len(Curves) == 0 {
+		return errors.New("invalid curve")
+	}
+	CurveID = Curves[0]
+
+	return nil
+}
+
+// ParseFlagsSRS parses the flags of the srs command, the size being the number of points of the SRS
+func ParseFlagsSRS(config *Config) error {
+	if *config.CircuitSize < 2 {
+		return errors.New("srs size must be >= 2")
+	}
+	if *config.OutputPath == "None" {
+		return errors.New("missing output path")
+	}
+
+	CurveID = ecc.UNKNOWN
+	for _, id := range ecc.Implemented() {
+		if *config.Curve == strings.ToLower(id.String()) {
+			CurveID = id
+		}
+	}
+	if CurveID == ecc.UNKNOWN {
+		return errors.New("invalid curve")
+	}
+
+	return nil
+}
+
+// ParseFlagsMatrix parses the flags of the matrix command, which prints the support of --algo
+func ParseFlagsMatrix(config *Config) error {
+	if !slices.Contains(Algos, *config.Algo) {
+		return errors.New("invalid algo")
+	}
+	return nil
+}
+
+// ParseFlagsImport parses the flags of the import-r1cs command, whose curve is the one of the R1CS file
+func ParseFlagsImport(config *Config) error {
+	if *config.Count <= 0 {
+		return errors.New("bench count must be >= 0")
+	}
+
+	switch *config.Algo {
+	case "compile", "setup", "witness", "prove", "verify":
+	default:
+		return errors.New("invalid algo, must be compile, setup, witness, prove or verify")
+	}
+
+	return parseProfile(config)
+}
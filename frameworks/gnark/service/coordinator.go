@@ -0,0 +1,361 @@
+package service
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/zkCollective/zk-Harness/frameworks/gnark/util"
+)
+
+// ErrLeaseLost is returned when a worker pushes the result of a cell it no longer leases, the lease
+// having expired and the cell being leased to another worker
+var ErrLeaseLost = errors.New("the cell is not leased to the worker")
+
+// Cell is a run of a job leased to a worker
+type Cell struct {
+	Job string `json:"job"`
+	ID  int    `json:"id"`
+	Run Run    `json:"run"`
+}
+
+// CellResult is the outcome of a cell pushed by the worker: the records of the CSV its run wrote, the
+// first one being the header, and its output
+type CellResult struct {
+	Job     string     `json:"job"`
+	Cell    int        `json:"cell"`
+	Records [][]string `json:"records"`
+	Log     string     `json:"log"`
+	Error   string     `json:"error,omitempty"`
+}
+
+// WorkerStatus is the state of a registered worker served as JSON
+type WorkerStatus struct {
+	ID        string    `json:"id"`
+	Host      util.Host `json:"host"`
+	Leased    int       `json:"leased"`
+	Completed int       `json:"completed"`
+	LastSeen  time.Time `json:"lastSeen"`
+}
+
+type worker struct {
+	id        string
+	host      util.Host
+	completed int
+	lastSeen  time.Time
+}
+
+type cell struct {
+	run      Run
+	status   string
+	worker   *worker
+	deadline time.Time
+	records  [][]string
+	log      string
+	err      string
+}
+
+type clusterJob struct {
+	id        string
+	spec      Spec
+	cells     []*cell
+	skipped   []string
+	header    []string
+	submitted time.Time
+	started   time.Time
+	finished  time.Time
+}
+
+// Coordinator splits the submitted jobs into cells, a run each, leased to the workers pulling them. A lease
+// is renewed by the heartbeats of its worker and the cell is leased again once it expires, e.g. as the
+// worker died. Every worker runs one cell at a time, such that its timings are not disturbed.
+type Coordinator struct {
+	root string
+	ttl  time.Duration
+
+	mu      sync.Mutex
+	jobs    []*clusterJob
+	workers []*worker
+}
+
+// NewCoordinator returns a coordinator whose leases expire after ttl without heartbeat. The input paths of
+// the specs are relative to root.
+func NewCoordinator(root string, ttl time.Duration) *Coordinator {
+	return &Coordinator{root: root, ttl: ttl}
+}
+
+// Submit expands the spec into its cells and queues them after the cells of the previous jobs. The records
+// of the cells are merged under a single header, such that a spec whose runs write other headers is rejected.
+func (c *Coordinator) Submit(spec Spec) (JobStatus, error) {
+	runs, skipped, err := spec.Runs(c.root)
+	if err != nil {
+		return JobStatus{}, err
+	}
+	header := runs[0].Header()
+	for _, run := range runs {
+		if h := run.Header(); h == nil || !slices.Equal(h, header) {
+			return JobStatus{}, fmt.Errorf("the records of %s do not have the header of the job", run)
+		}
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	job := &clusterJob{
+		id:        strconv.Itoa(len(c.jobs) + 1),
+		spec:      spec,
+		skipped:   skipped,
+		header:    header,
+		submitted: time.Now(),
+	}
+	for _, run := range runs {
+		job.cells = append(job.cells, &cell{run: run, status: StatusQueued})
+	}
+	c.jobs = append(c.jobs, job)
+	return job.status(), nil
+}
+
+// Register registers a worker on the host, which renews its leases three times per ttl
+func (c *Coordinator) Register(host util.Host) Registration {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	w := &worker{id: "w" + strconv.Itoa(len(c.workers)+1), host: host, lastSeen: time.Now()}
+	c.workers = append(c.workers, w)
+	return Registration{ID: w.id, Heartbeat: c.ttl / 3}
+}
+
+// Lease leases the next queued cell, or a cell whose lease expired, to the worker. It returns false
+// when no cell is left to run.
+func (c *Coordinator) Lease(workerID string) (Cell, bool, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	w, err := c.seen(workerID)
+	if err != nil {
+		return Cell{}, false, err
+	}
+
+	now := time.Now()
+	for _, job := range c.jobs {
+		for i, cl := range job.cells {
+			expired := cl.status == StatusRunning && now.After(cl.deadline)
+			if cl.status != StatusQueued && !expired {
+				continue
+			}
+			cl.status, cl.worker, cl.deadline = StatusRunning, w, now.Add(c.ttl)
+			if job.started.IsZero() {
+				job.started = now
+			}
+			return Cell{Job: job.id, ID: i, Run: cl.run}, true, nil
+		}
+	}
+	return Cell{}, false, nil
+}
+
+// Heartbeat renews the leases of the worker
+func (c *Coordinator) Heartbeat(workerID string) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	w, err := c.seen(workerID)
+	if err != nil {
+		return err
+	}
+	for _, job := range c.jobs {
+		for _, cl := range job.cells {
+			if cl.status == StatusRunning && cl.worker == w {
+				cl.deadline = w.lastSeen.Add(c.ttl)
+			}
+		}
+	}
+	return nil
+}
+
+// Push records the result of a cell leased to the worker
+func (c *Coordinator) Push(workerID string, result CellResult) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	w, err := c.seen(workerID)
+	if err != nil {
+		return err
+	}
+	job, err := c.job(result.Job)
+	if err != nil {
+		return err
+	}
+	if result.Cell < 0 || result.Cell >= len(job.cells) {
+		return fmt.Errorf("unknown cell %d of job %s", result.Cell, job.id)
+	}
+	cl := job.cells[result.Cell]
+	if cl.status != StatusRunning || cl.worker != w {
+		return ErrLeaseLost
+	}
+
+	cl.log, cl.err = result.Log, result.Error
+	if len(result.Records) > 0 && !slices.Equal(job.header, result.Records[0]) {
+		cl.err = "the results have another header than the ones of the job"
+	}
+	if cl.err != "" {
+		cl.status = StatusFailed
+	} else {
+		cl.status, cl.records = StatusDone, result.Records[min(1, len(result.Records)):]
+	}
+	w.completed++
+
+	for _, cl := range job.cells {
+		if cl.status == StatusQueued || cl.status == StatusRunning {
+			return nil
+		}
+	}
+	job.finished = time.Now()
+	return nil
+}
+
+// Jobs returns the status of the jobs in the order of submission
+func (c *Coordinator) Jobs() []JobStatus {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	statuses := []JobStatus{}
+	for _, job := range c.jobs {
+		statuses = append(statuses, job.status())
+	}
+	return statuses
+}
+
+// Job returns the status of the job with the id
+func (c *Coordinator) Job(id string) (JobStatus, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	job, err := c.job(id)
+	if err != nil {
+		return JobStatus{}, err
+	}
+	return job.status(), nil
+}
+
+// Workers returns the status of the registered workers
+func (c *Coordinator) Workers() []WorkerStatus {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	statuses := []WorkerStatus{}
+	for _, w := range c.workers {
+		s := WorkerStatus{ID: w.id, Host: w.host, Completed: w.completed, LastSeen: w.lastSeen}
+		for _, job := range c.jobs {
+			for _, cl := range job.cells {
+				if cl.status == StatusRunning && cl.worker == w {
+					s.Leased++
+				}
+			}
+		}
+		statuses = append(statuses, s)
+	}
+	return statuses
+}
+
+// Results returns the results of the job merged so far, every row tagged with the host and the worker
+// that ran it, the first record being the header
+func (c *Coordinator) Results(id string) ([][]string, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	job, err := c.job(id)
+	if err != nil {
+		return nil, err
+	}
+	records := [][]string{append([]string{"host", "worker"}, job.header...)}
+	for _, cl := range job.cells {
+		for _, record := range cl.records {
+			records = append(records, append([]string{cl.worker.host.Name, cl.worker.id}, record...))
+		}
+	}
+	if len(records) == 1 {
+		return nil, nil
+	}
+	return records, nil
+}
+
+// Logs returns the output of the cells of the job run so far
+func (c *Coordinator) Logs(id string) (string, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	job, err := c.job(id)
+	if err != nil {
+		return "", err
+	}
+	var b strings.Builder
+	for _, skipped := range job.skipped {
+		b.WriteString("skipped " + skipped + "\n")
+	}
+	for i, cl := range job.cells {
+		if cl.status != StatusDone && cl.status != StatusFailed {
+			continue
+		}
+		fmt.Fprintf(&b, "=== run %d/%d on %s (%s): %s\n", i+1, len(job.cells), cl.worker.host.Name, cl.worker.id, cl.run)
+		b.WriteString(cl.log)
+		if cl.err != "" {
+			b.WriteString("error: " + cl.err + "\n")
+		}
+	}
+	return b.String(), nil
+}
+
+// seen returns the worker with the id, which has just been seen
+func (c *Coordinator) seen(workerID string) (*worker, error) {
+	for _, w := range c.workers {
+		if w.id == workerID {
+			w.lastSeen = time.Now()
+			return w, nil
+		}
+	}
+	return nil, errUnknown("worker", workerID)
+}
+
+func (c *Coordinator) job(id string) (*clusterJob, error) {
+	for _, job := range c.jobs {
+		if job.id == id {
+			return job, nil
+		}
+	}
+	return nil, errUnknown("job", id)
+}
+
+func (job *clusterJob) status() JobStatus {
+	s := JobStatus{
+		ID:        job.id,
+		Project:   job.spec.Project,
+		Status:    StatusQueued,
+		Runs:      len(job.cells),
+		Skipped:   job.skipped,
+		Submitted: job.submitted,
+	}
+	for _, cl := range job.cells {
+		switch cl.status {
+		case StatusDone:
+			s.Completed++
+		case StatusFailed:
+			s.Failed++
+		}
+	}
+	if !job.started.IsZero() {
+		s.Status, s.Started = StatusRunning, &job.started
+	}
+	if !job.finished.IsZero() {
+		s.Status, s.Finished = StatusDone, &job.finished
+		if s.Failed > 0 {
+			s.Status = StatusFailed
+			s.Error = fmt.Sprintf("%d of %d runs failed", s.Failed, s.Runs)
+		}
+	}
+	return s
+}
+
+// unknownError is returned for an unknown job or worker
+type unknownError string
+
+func (err unknownError) Error() string {
+	return string(err)
+}
+
+func errUnknown(kind string, id string) error {
+	return unknownError("unknown " + kind + " " + id)
+}
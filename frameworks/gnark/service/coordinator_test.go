@@ -0,0 +1,211 @@
+package service
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/consensys/gnark/logger"
+	"github.com/consensys/gnark/test"
+	"github.com/zkCollective/zk-Harness/frameworks/gnark/util"
+)
+
+func TestCoordinator(t *testing.T) {
+	assert := test.NewAssert(t)
+	logger.Disable()
+
+	c := NewCoordinator(writeInputs(t), time.Minute)
+	server := httptest.NewServer(c.Handler())
+	defer server.Close()
+	client := coordinatorClient{assert: assert, url: server.URL}
+
+	var status JobStatus
+	client.post("/jobs", spec, http.StatusAccepted, &status)
+	assert.Equal(40, status.Runs)
+	assert.Equal(StatusQueued, status.Status)
+
+	// every worker holds its first cell until the three cells are leased, such that every host runs some
+	ctx, cancel := context.WithCancel(context.Background())
+	var wg sync.WaitGroup
+	release := make(chan struct{})
+	runners := make(map[string]*fakeRunner)
+	for _, name := range []string{"alpha", "beta", "gamma"} {
+		runner := newFakeRunner(0)
+		runner.release = release
+		runners[name] = runner
+		w := NewWorker(server.URL, util.Host{Name: name, Gnark: "v0.10.0"}, runner.run, t.TempDir(), time.Millisecond)
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			w.Run(ctx)
+		}()
+	}
+	var workers []WorkerStatus
+	assert.Eventually(func() bool {
+		client.get("/workers", &workers)
+		return len(workers) == 3 && workers[0].Leased+workers[1].Leased+workers[2].Leased == 3
+	}, time.Minute, time.Millisecond)
+	close(release)
+
+	assert.Eventually(func() bool {
+		client.get("/jobs/1", &status)
+		return status.Status == StatusDone
+	}, time.Minute, time.Millisecond)
+	cancel()
+	wg.Wait()
+	assert.Equal(40, status.Completed)
+
+	// the results are merged, tagged by the host of the worker that ran them
+	hosts := make(map[string]string)
+	completed := 0
+	client.get("/workers", &workers)
+	for _, w := range workers {
+		hosts[w.ID] = w.Host.Name
+		assert.Equal("v0.10.0", w.Host.Gnark)
+		assert.Equal(0, w.Leased)
+		runs, maxRunning := runners[w.Host.Name].count()
+		assert.Equal(runs, w.Completed)
+		assert.Equal(1, maxRunning)
+		completed += w.Completed
+	}
+	assert.Equal(40, completed)
+
+	var rows []map[string]string
+	client.get("/jobs/1/results", &rows)
+	assert.Len(rows, 40)
+	perHost := make(map[string]int)
+	for _, row := range rows {
+		assert.Equal(hosts[row["worker"]], row["host"])
+		perHost[row["host"]]++
+	}
+	for name, runner := range runners {
+		runs, _ := runner.count()
+		assert.Equal(runs, perHost[name], name)
+	}
+	assert.Equal("groth16", rows[0]["backend"])
+	assert.Equal("input/circuit/cubic/input_1.json", rows[0]["input"])
+
+	resp, err := http.Get(server.URL + "/jobs/1/results?format=csv")
+	assert.NoError(err)
+	var csv bytes.Buffer
+	csv.ReadFrom(resp.Body)
+	resp.Body.Close()
+	assert.True(strings.HasPrefix(csv.String(), "host,worker,framework,category,backend,"))
+
+	resp, err = http.Get(server.URL + "/jobs/1/logs")
+	assert.NoError(err)
+	var logs bytes.Buffer
+	logs.ReadFrom(resp.Body)
+	resp.Body.Close()
+	assert.Equal(40, strings.Count(logs.String(), "BENCHMARKING"))
+	assert.Contains(logs.String(), "skipped groth16 mimc prove bls24_317")
+}
+
+func TestCoordinatorLeases(t *testing.T) {
+	assert := test.NewAssert(t)
+	logger.Disable()
+
+	const ttl = 200 * time.Millisecond
+	c := NewCoordinator(writeInputs(t), ttl)
+	server := httptest.NewServer(c.Handler())
+	defer server.Close()
+	client := coordinatorClient{assert: assert, url: server.URL}
+	client.post("/jobs", strings.Replace(spec, `"count": 2`, `"count": 1`, 1), http.StatusAccepted, nil)
+
+	// a worker leasing a cell and dying loses it once its lease expired
+	var registration Registration
+	client.post("/workers", `{"name": "dead"}`, http.StatusCreated, &registration)
+	assert.Equal(ttl/3, registration.Heartbeat)
+	var cell Cell
+	client.post("/workers/"+registration.ID+"/lease", "", http.StatusOK, &cell)
+	assert.Equal(Cell{Job: "1", ID: 0, Run: Run{Backend: "groth16", Curve: "bn254", Circuit: "cubic", Input: "input/circuit/cubic/input_1.json", Algo: "prove"}}, cell)
+
+	// the runs of the live worker last longer than the lease, which its heartbeats renew
+	runner := newFakeRunner(ttl * 3 / 2)
+	close(runner.release)
+	w := NewWorker(server.URL, util.Host{Name: "live"}, runner.run, t.TempDir(), time.Millisecond)
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	go w.Run(ctx)
+
+	var status JobStatus
+	assert.Eventually(func() bool {
+		client.get("/jobs/1", &status)
+		return status.Status == StatusDone
+	}, time.Minute, time.Millisecond)
+	assert.Equal(20, status.Completed)
+	runs, _ := runner.count()
+	assert.Equal(20, runs, "every cell runs once")
+
+	client.post("/workers/"+registration.ID+"/results", `{"job": "1", "cell": 0, "records": [["a"], ["b"]]}`, http.StatusConflict, nil)
+	client.post("/workers/"+registration.ID+"/lease", "", http.StatusNoContent, nil)
+	client.post("/workers/unknown/lease", "", http.StatusNotFound, nil)
+
+	var rows []map[string]string
+	client.get("/jobs/1/results", &rows)
+	assert.Len(rows, 20)
+	for _, row := range rows {
+		assert.Equal("live", row["host"])
+	}
+}
+
+func TestCoordinatorHeaders(t *testing.T) {
+	assert := test.NewAssert(t)
+
+	// the runs of a job share the header of the circuit records, the other algorithms having none
+	assert.Equal(util.BenchDataCircuit{}.Headers(), Run{Algo: "prove"}.Header())
+	assert.Nil(Run{Algo: "serialize"}.Header())
+	assert.Nil(Run{Algo: "export-solidity"}.Header())
+
+	c := NewCoordinator(writeInputs(t), time.Minute)
+	var s Spec
+	assert.NoError(json.Unmarshal([]byte(strings.Replace(spec, `"count": 2`, `"count": 1`, 1)), &s))
+	_, err := c.Submit(s)
+	assert.NoError(err)
+	records, err := c.Results("1")
+	assert.NoError(err)
+	assert.Nil(records, "no results before the first cell")
+
+	// a result with another header than the one of the job fails its cell
+	registration := c.Register(util.Host{Name: "alpha"})
+	cell, ok, err := c.Lease(registration.ID)
+	assert.NoError(err)
+	assert.True(ok)
+	assert.NoError(c.Push(registration.ID, CellResult{Job: cell.Job, Cell: cell.ID, Records: [][]string{{"operation"}, {"prove"}}}))
+	status, err := c.Job("1")
+	assert.NoError(err)
+	assert.Equal(1, status.Failed)
+	records, err = c.Results("1")
+	assert.NoError(err)
+	assert.Nil(records)
+}
+
+// coordinatorClient posts and gets the JSON of the API of a coordinator
+type coordinatorClient struct {
+	assert *test.Assert
+	url    string
+}
+
+func (c coordinatorClient) post(path string, body string, code int, v interface{}) {
+	resp, err := http.Post(c.url+path, "application/json", strings.NewReader(body))
+	c.assert.NoError(err)
+	defer resp.Body.Close()
+	c.assert.Equal(code, resp.StatusCode, path)
+	if v != nil {
+		c.assert.NoError(json.NewDecoder(resp.Body).Decode(v))
+	}
+}
+
+func (c coordinatorClient) get(path string, v interface{}) {
+	resp, err := http.Get(c.url + path)
+	c.assert.NoError(err)
+	defer resp.Body.Close()
+	c.assert.Equal(http.StatusOK, resp.StatusCode, path)
+	c.assert.NoError(json.NewDecoder(resp.Body).Decode(v))
+}
@@ -0,0 +1,318 @@
+package service
+
+import (
+	"bytes"
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"io/fs"
+	"net/http"
+	"os"
+	"strings"
+
+	"github.com/zkCollective/zk-Harness/frameworks/gnark/util"
+)
+
+// Handler returns the HTTP API of the service:
+//
+//	POST /jobs                              submits a spec, answers the status of the queued job
+//	GET  /jobs                              lists the status of the jobs
+//	GET  /jobs/{id}                         answers the status of a job
+//	GET  /jobs/{id}/logs                    streams the output of a job until it finishes
+//	GET  /jobs/{id}/results?format=csv|json answers the results of a job, json by default
+func (s *Service) Handler() http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		path := strings.Split(strings.Trim(r.URL.Path, "/"), "/")
+		if path[0] != "jobs" || len(path) > 3 {
+			http.NotFound(w, r)
+			return
+		}
+		if len(path) == 1 {
+			switch r.Method {
+			case http.MethodPost:
+				s.submit(w, r)
+			case http.MethodGet:
+				s.list(w)
+			default:
+				w.Header().Set("Allow", "GET, POST")
+				http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+			}
+			return
+		}
+
+		if r.Method != http.MethodGet {
+			w.Header().Set("Allow", "GET")
+			http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+			return
+		}
+		job, ok := s.Job(path[1])
+		if !ok {
+			http.Error(w, "unknown job "+path[1], http.StatusNotFound)
+			return
+		}
+		if len(path) == 2 {
+			writeJSON(w, http.StatusOK, job.Status())
+			return
+		}
+		switch path[2] {
+		case "logs":
+			streamLogs(w, r, job)
+		case "results":
+			writeResults(w, r, job)
+		default:
+			http.NotFound(w, r)
+		}
+	})
+}
+
+func (s *Service) submit(w http.ResponseWriter, r *http.Request) {
+	var spec Spec
+	if err := json.NewDecoder(r.Body).Decode(&spec); err != nil {
+		http.Error(w, "invalid spec: "+err.Error(), http.StatusBadRequest)
+		return
+	}
+	job, err := s.Submit(spec)
+	if errors.Is(err, ErrQueueFull) {
+		http.Error(w, err.Error(), http.StatusServiceUnavailable)
+		return
+	}
+	if err != nil {
+		http.Error(w, "invalid spec: "+err.Error(), http.StatusBadRequest)
+		return
+	}
+	w.Header().Set("Location", "/jobs/"+job.id)
+	writeJSON(w, http.StatusAccepted, job.Status())
+}
+
+func (s *Service) list(w http.ResponseWriter) {
+	statuses := []JobStatus{}
+	for _, job := range s.Jobs() {
+		statuses = append(statuses, job.Status())
+	}
+	writeJSON(w, http.StatusOK, statuses)
+}
+
+// streamLogs writes the output of the job as it is produced, until the job finishes or the client leaves
+func streamLogs(w http.ResponseWriter, r *http.Request, job *Job) {
+	w.Header().Set("Content-Type", "text/plain; charset=utf-8")
+	flusher, _ := w.(http.Flusher)
+	offset := 0
+	for {
+		b, changed, closed := job.log.next(offset)
+		if len(b) > 0 {
+			if _, err := w.Write(b); err != nil {
+				return
+			}
+			if flusher != nil {
+				flusher.Flush()
+			}
+			offset += len(b)
+			continue
+		}
+		if closed {
+			return
+		}
+		select {
+		case <-changed:
+		case <-r.Context().Done():
+			return
+		}
+	}
+}
+
+// writeResults writes the results of the job written so far
+func writeResults(w http.ResponseWriter, r *http.Request, job *Job) {
+	b, err := os.ReadFile(job.results)
+	if err != nil && !errors.Is(err, fs.ErrNotExist) {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	records, err := csv.NewReader(bytes.NewReader(b)).ReadAll()
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	writeRecords(w, r, records)
+}
+
+// writeRecords writes the records of a CSV, the first one being the header, in the format of the query:
+// the CSV itself or, for json, the default, the rows as objects
+func writeRecords(w http.ResponseWriter, r *http.Request, records [][]string) {
+	switch r.URL.Query().Get("format") {
+	case "csv":
+		w.Header().Set("Content-Type", "text/csv")
+		csv.NewWriter(w).WriteAll(records)
+	case "", "json":
+		rows := []map[string]string{}
+		for i := 1; i < len(records); i++ {
+			row := make(map[string]string, len(records[0]))
+			for j, column := range records[0] {
+				row[column] = records[i][j]
+			}
+			rows = append(rows, row)
+		}
+		writeJSON(w, http.StatusOK, rows)
+	default:
+		http.Error(w, "invalid format, must be csv or json", http.StatusBadRequest)
+	}
+}
+
+func writeJSON(w http.ResponseWriter, code int, v interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(code)
+	json.NewEncoder(w).Encode(v)
+}
+
+// Handler returns the HTTP API of the coordinator, answering the requests of the clients as the service
+// and the ones of the workers:
+//
+//	POST /jobs                              submits a spec, answers the status of the queued job
+//	GET  /jobs                              lists the status of the jobs
+//	GET  /jobs/{id}                         answers the status of a job
+//	GET  /jobs/{id}/logs                    answers the output of the cells of a job run so far
+//	GET  /jobs/{id}/results?format=csv|json answers the results of a job tagged by host, json by default
+//	POST /workers                           registers the host of a worker, answers its id
+//	GET  /workers                           lists the status of the workers
+//	POST /workers/{id}/lease                leases a cell to a worker, 204 if none is left
+//	POST /workers/{id}/heartbeat            renews the leases of a worker
+//	POST /workers/{id}/results              pushes the result of a cell, 409 if its lease was lost
+func (c *Coordinator) Handler() http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		path := strings.Split(strings.Trim(r.URL.Path, "/"), "/")
+		if (path[0] != "jobs" && path[0] != "workers") || len(path) > 3 {
+			http.NotFound(w, r)
+			return
+		}
+		if len(path) == 1 {
+			switch {
+			case r.Method == http.MethodPost && path[0] == "jobs":
+				var spec Spec
+				if err := json.NewDecoder(r.Body).Decode(&spec); err != nil {
+					http.Error(w, "invalid spec: "+err.Error(), http.StatusBadRequest)
+					return
+				}
+				status, err := c.Submit(spec)
+				if err != nil {
+					http.Error(w, "invalid spec: "+err.Error(), http.StatusBadRequest)
+					return
+				}
+				w.Header().Set("Location", "/jobs/"+status.ID)
+				writeJSON(w, http.StatusAccepted, status)
+			case r.Method == http.MethodPost:
+				var host util.Host
+				if err := json.NewDecoder(r.Body).Decode(&host); err != nil {
+					http.Error(w, "invalid host: "+err.Error(), http.StatusBadRequest)
+					return
+				}
+				writeJSON(w, http.StatusCreated, c.Register(host))
+			case r.Method == http.MethodGet && path[0] == "jobs":
+				writeJSON(w, http.StatusOK, c.Jobs())
+			case r.Method == http.MethodGet:
+				writeJSON(w, http.StatusOK, c.Workers())
+			default:
+				w.Header().Set("Allow", "GET, POST")
+				http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+			}
+			return
+		}
+
+		if path[0] == "jobs" {
+			c.serveJob(w, r, path[1:])
+		} else {
+			c.serveWorker(w, r, path[1:])
+		}
+	})
+}
+
+func (c *Coordinator) serveJob(w http.ResponseWriter, r *http.Request, path []string) {
+	if r.Method != http.MethodGet {
+		w.Header().Set("Allow", "GET")
+		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+	if len(path) == 1 {
+		status, err := c.Job(path[0])
+		if err != nil {
+			writeError(w, err)
+			return
+		}
+		writeJSON(w, http.StatusOK, status)
+		return
+	}
+	switch path[1] {
+	case "logs":
+		logs, err := c.Logs(path[0])
+		if err != nil {
+			writeError(w, err)
+			return
+		}
+		w.Header().Set("Content-Type", "text/plain; charset=utf-8")
+		w.Write([]byte(logs))
+	case "results":
+		records, err := c.Results(path[0])
+		if err != nil {
+			writeError(w, err)
+			return
+		}
+		writeRecords(w, r, records)
+	default:
+		http.NotFound(w, r)
+	}
+}
+
+func (c *Coordinator) serveWorker(w http.ResponseWriter, r *http.Request, path []string) {
+	if len(path) != 2 {
+		http.NotFound(w, r)
+		return
+	}
+	if r.Method != http.MethodPost {
+		w.Header().Set("Allow", "POST")
+		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+	switch path[1] {
+	case "lease":
+		cell, ok, err := c.Lease(path[0])
+		if err != nil {
+			writeError(w, err)
+			return
+		}
+		if !ok {
+			w.WriteHeader(http.StatusNoContent)
+			return
+		}
+		writeJSON(w, http.StatusOK, cell)
+	case "heartbeat":
+		if err := c.Heartbeat(path[0]); err != nil {
+			writeError(w, err)
+			return
+		}
+		w.WriteHeader(http.StatusNoContent)
+	case "results":
+		var result CellResult
+		if err := json.NewDecoder(r.Body).Decode(&result); err != nil {
+			http.Error(w, "invalid result: "+err.Error(), http.StatusBadRequest)
+			return
+		}
+		if err := c.Push(path[0], result); err != nil {
+			writeError(w, err)
+			return
+		}
+		w.WriteHeader(http.StatusNoContent)
+	default:
+		http.NotFound(w, r)
+	}
+}
+
+// writeError answers the error of the coordinator with its status code
+func writeError(w http.ResponseWriter, err error) {
+	var unknown unknownError
+	switch {
+	case errors.As(err, &unknown):
+		http.Error(w, err.Error(), http.StatusNotFound)
+	case errors.Is(err, ErrLeaseLost):
+		http.Error(w, err.Error(), http.StatusConflict)
+	default:
+		http.Error(w, err.Error(), http.StatusBadRequest)
+	}
+}
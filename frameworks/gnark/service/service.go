@@ -0,0 +1,263 @@
+// Package service serves the harness over HTTP: benchmark specs are submitted as jobs, queued and run
+// one at a time, such that the timings of a job are not disturbed by the others, or split by a coordinator
+// into cells pulled by a pool of workers.
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strconv"
+	"sync"
+	"time"
+)
+
+// Statuses of a job
+const (
+	StatusQueued  = "queued"
+	StatusRunning = "running"
+	StatusDone    = "done"
+	StatusFailed  = "failed"
+)
+
+// ErrQueueFull is returned when a job is submitted while the queue is full
+var ErrQueueFull = errors.New("the job queue is full")
+
+// Runner runs a benchmark, appending its results to the CSV at outputPath and its output to log
+type Runner func(ctx context.Context, run Run, outputPath string, log io.Writer) error
+
+// ExecRunner runs every benchmark in a child process of the harness binary at path
+func ExecRunner(path string) Runner {
+	return func(ctx context.Context, run Run, outputPath string, log io.Writer) error {
+		cmd := exec.CommandContext(ctx, path, run.Args(outputPath)...)
+		cmd.Stdout = log
+		cmd.Stderr = log
+		return cmd.Run()
+	}
+}
+
+// Job is a submitted spec and the state of its runs
+type Job struct {
+	mu        sync.Mutex
+	id        string
+	spec      Spec
+	runs      []Run
+	skipped   []string
+	status    string
+	completed int
+	failed    int
+	err       string
+	submitted time.Time
+	started   time.Time
+	finished  time.Time
+
+	log     logBuffer
+	results string
+}
+
+// JobStatus is the state of a job served as JSON
+type JobStatus struct {
+	ID        string     `json:"id"`
+	Project   string     `json:"project"`
+	Status    string     `json:"status"`
+	Runs      int        `json:"runs"`
+	Completed int        `json:"completed"`
+	Failed    int        `json:"failed"`
+	Skipped   []string   `json:"skipped,omitempty"`
+	Error     string     `json:"error,omitempty"`
+	Submitted time.Time  `json:"submitted"`
+	Started   *time.Time `json:"started,omitempty"`
+	Finished  *time.Time `json:"finished,omitempty"`
+}
+
+// Status returns the state of the job
+func (j *Job) Status() JobStatus {
+	j.mu.Lock()
+	defer j.mu.Unlock()
+	s := JobStatus{
+		ID:        j.id,
+		Project:   j.spec.Project,
+		Status:    j.status,
+		Runs:      len(j.runs),
+		Completed: j.completed,
+		Failed:    j.failed,
+		Skipped:   j.skipped,
+		Error:     j.err,
+		Submitted: j.submitted,
+	}
+	if !j.started.IsZero() {
+		s.Started = &j.started
+	}
+	if !j.finished.IsZero() {
+		s.Finished = &j.finished
+	}
+	return s
+}
+
+func (j *Job) setStatus(status string, update func()) {
+	j.mu.Lock()
+	defer j.mu.Unlock()
+	j.status = status
+	if update != nil {
+		update()
+	}
+}
+
+// Service queues the submitted jobs and runs them one at a time
+type Service struct {
+	runner Runner
+	root   string
+	dir    string
+	queue  chan *Job
+
+	mu     sync.Mutex
+	jobs   map[string]*Job
+	order  []*Job
+	nextID int
+}
+
+// New returns a service running the benchmarks with runner, queuing up to capacity jobs. The input paths of
+// the specs are relative to root and the results of every job are written in dir.
+func New(runner Runner, root string, dir string, capacity int) *Service {
+	return &Service{
+		runner: runner,
+		root:   root,
+		dir:    dir,
+		queue:  make(chan *Job, capacity),
+		jobs:   make(map[string]*Job),
+	}
+}
+
+// Submit expands the spec into its runs and queues the job
+func (s *Service) Submit(spec Spec) (*Job, error) {
+	runs, skipped, err := spec.Runs(s.root)
+	if err != nil {
+		return nil, err
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	id := strconv.Itoa(s.nextID + 1)
+	job := &Job{
+		id:        id,
+		spec:      spec,
+		runs:      runs,
+		skipped:   skipped,
+		status:    StatusQueued,
+		submitted: time.Now(),
+		results:   filepath.Join(s.dir, id+".csv"),
+	}
+	job.log.init()
+	select {
+	case s.queue <- job:
+	default:
+		return nil, ErrQueueFull
+	}
+	s.nextID++
+	s.jobs[id] = job
+	s.order = append(s.order, job)
+	return job, nil
+}
+
+// Job returns the job with the id
+func (s *Service) Job(id string) (*Job, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	job, ok := s.jobs[id]
+	return job, ok
+}
+
+// Jobs returns the jobs in the order of submission
+func (s *Service) Jobs() []*Job {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return append([]*Job(nil), s.order...)
+}
+
+// Run runs the queued jobs, one at a time, until the context is done
+func (s *Service) Run(ctx context.Context) error {
+	if err := os.MkdirAll(s.dir, 0755); err != nil {
+		return err
+	}
+	for {
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case job := <-s.queue:
+			s.run(ctx, job)
+		}
+	}
+}
+
+func (s *Service) run(ctx context.Context, job *Job) {
+	defer job.log.close()
+	job.setStatus(StatusRunning, func() { job.started = time.Now() })
+
+	for _, skipped := range job.skipped {
+		fmt.Fprintln(&job.log, "skipped "+skipped)
+	}
+	for i, run := range job.runs {
+		fmt.Fprintf(&job.log, "=== run %d/%d: %s\n", i+1, len(job.runs), run)
+		err := s.runner(ctx, run, job.results, &job.log)
+		job.mu.Lock()
+		if err != nil {
+			job.failed++
+			fmt.Fprintln(&job.log, "error: "+err.Error())
+		} else {
+			job.completed++
+		}
+		job.mu.Unlock()
+		if ctx.Err() != nil {
+			job.setStatus(StatusFailed, func() { job.err = "the service stopped"; job.finished = time.Now() })
+			return
+		}
+	}
+
+	job.setStatus(StatusDone, func() {
+		if job.failed > 0 {
+			job.status = StatusFailed
+			job.err = fmt.Sprintf("%d of %d runs failed", job.failed, len(job.runs))
+		}
+		job.finished = time.Now()
+	})
+}
+
+// logBuffer is the output of a job, followed by the readers until it is closed
+type logBuffer struct {
+	mu      sync.Mutex
+	b       []byte
+	closed  bool
+	changed chan struct{}
+}
+
+func (l *logBuffer) init() {
+	l.changed = make(chan struct{})
+}
+
+func (l *logBuffer) Write(p []byte) (int, error) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.b = append(l.b, p...)
+	close(l.changed)
+	l.changed = make(chan struct{})
+	return len(p), nil
+}
+
+func (l *logBuffer) close() {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.closed = true
+	close(l.changed)
+	l.changed = make(chan struct{})
+}
+
+// next returns the log from offset, a channel closed on the next change and whether the log is closed
+func (l *logBuffer) next(offset int) ([]byte, <-chan struct{}, bool) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	return append([]byte(nil), l.b[offset:]...), l.changed, l.closed
+}
@@ -0,0 +1,259 @@
+package service
+
+import (
+	"context"
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/consensys/gnark/test"
+	"github.com/zkCollective/zk-Harness/frameworks/gnark/util"
+)
+
+const spec = `{
+	"project": "gnark",
+	"project_url": "https://github.com/Consensys/gnark",
+	"category": "circuit",
+	"count": 2,
+	"payload": {
+		"backend": ["groth16", "plonk"],
+		"curves": ["bn254", "bls24_317"],
+		"circuits": {
+			"cubic": {"input_path": "input/circuit/cubic"},
+			"mimc": {"input_path": ["input/circuit/mimc/input.json"]}
+		},
+		"algorithm": ["prove", "verify"]
+	}
+}`
+
+// fakeRunner writes a result per run, holding every run until release is closed and then for delay, and
+// records the number of runs and the highest number of concurrent runs
+type fakeRunner struct {
+	release chan struct{}
+	delay   time.Duration
+
+	mu         sync.Mutex
+	runs       int
+	running    int
+	maxRunning int
+}
+
+func newFakeRunner(delay time.Duration) *fakeRunner {
+	return &fakeRunner{release: make(chan struct{}), delay: delay}
+}
+
+func (f *fakeRunner) run(ctx context.Context, run Run, outputPath string, log io.Writer) error {
+	f.mu.Lock()
+	f.runs++
+	f.running++
+	if f.running > f.maxRunning {
+		f.maxRunning = f.running
+	}
+	f.mu.Unlock()
+	defer func() {
+		f.mu.Lock()
+		f.running--
+		f.mu.Unlock()
+	}()
+
+	<-f.release
+	time.Sleep(f.delay)
+	fmt.Fprintln(log, "BENCHMARKING "+run.Circuit)
+	return util.WriteData("csv", util.BenchDataCircuit{
+		Framework: "gnark",
+		Category:  "circuit",
+		Backend:   run.Backend,
+		Curve:     run.Curve,
+		Circuit:   run.Circuit,
+		Input:     run.Input,
+		Operation: run.Algo,
+		Count:     1,
+	}, outputPath)
+}
+
+// count returns the number of runs and the highest number of concurrent runs
+func (f *fakeRunner) count() (int, int) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return f.runs, f.maxRunning
+}
+
+func TestService(t *testing.T) {
+	assert := test.NewAssert(t)
+
+	root := writeInputs(t)
+	runner := newFakeRunner(0)
+	s := New(runner.run, root, t.TempDir(), 2)
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	go s.Run(ctx)
+	server := httptest.NewServer(s.Handler())
+	defer server.Close()
+
+	submit := func(body string) *http.Response {
+		resp, err := http.Post(server.URL+"/jobs", "application/json", strings.NewReader(body))
+		assert.NoError(err)
+		return resp
+	}
+	get := func(path string, v interface{}) *http.Response {
+		resp, err := http.Get(server.URL + path)
+		assert.NoError(err)
+		if v != nil {
+			defer resp.Body.Close()
+			assert.Equal(http.StatusOK, resp.StatusCode, path)
+			assert.NoError(json.NewDecoder(resp.Body).Decode(v))
+		}
+		return resp
+	}
+
+	// the first job runs, the next two wait in the queue and a fourth one is refused
+	var status JobStatus
+	resp := submit(spec)
+	assert.Equal(http.StatusAccepted, resp.StatusCode)
+	assert.NoError(json.NewDecoder(resp.Body).Decode(&status))
+	resp.Body.Close()
+	// cubic over both curves for both backends, mimc over bn254 for both backends, with 2 inputs for cubic
+	assert.Equal(2*2*(2*2+1)*2, status.Runs)
+	assert.Len(status.Skipped, 2*2, "mimc over bls24_317")
+	assert.Eventually(func() bool { return get("/jobs/1", &status) != nil && status.Status == StatusRunning }, time.Minute, time.Millisecond)
+	resp = submit(spec)
+	assert.Equal(http.StatusAccepted, resp.StatusCode)
+	resp.Body.Close()
+	resp = submit(spec)
+	assert.Equal(http.StatusAccepted, resp.StatusCode)
+	resp.Body.Close()
+	resp = submit(spec)
+	assert.Equal(http.StatusServiceUnavailable, resp.StatusCode)
+	resp.Body.Close()
+
+	// invalid specs
+	for _, body := range []string{
+		`{`,
+		`{"category": "recursion", "count": 1}`,
+		strings.Replace(spec, `"count": 2`, `"count": 0`, 1),
+		strings.Replace(spec, "input/circuit/cubic", "input/circuit/missing", 1),
+		strings.Replace(spec, "input/circuit/cubic", "../input/circuit/cubic", 1),
+		strings.Replace(spec, `"groth16", "plonk"`, `"plonkFRI"`, 1),
+	} {
+		resp = submit(body)
+		assert.Equal(http.StatusBadRequest, resp.StatusCode, body)
+		resp.Body.Close()
+	}
+	resp = get("/jobs/42", nil)
+	assert.Equal(http.StatusNotFound, resp.StatusCode)
+	resp.Body.Close()
+
+	// the logs are streamed until the first job finishes
+	resp = get("/jobs/1/logs", nil)
+	assert.Equal(http.StatusOK, resp.StatusCode)
+	close(runner.release)
+	logs, err := io.ReadAll(resp.Body)
+	assert.NoError(err)
+	resp.Body.Close()
+	assert.Contains(string(logs), "skipped groth16 mimc prove bls24_317")
+	assert.Contains(string(logs), "=== run 40/40: plonk --circuit=cubic --algo=verify --curve=bls24_317 --input=input/circuit/cubic/input_2.json")
+	assert.Equal(40, strings.Count(string(logs), "BENCHMARKING"))
+
+	get("/jobs/1", &status)
+	assert.Equal(StatusDone, status.Status)
+	assert.Equal(40, status.Completed)
+	assert.NotNil(status.Finished)
+
+	var rows []map[string]string
+	get("/jobs/1/results", &rows)
+	assert.Len(rows, 40)
+	assert.Equal("groth16", rows[0]["backend"])
+	assert.Equal("input/circuit/cubic/input_1.json", rows[0]["input"])
+	assert.Equal("prove", rows[0]["operation"])
+	assert.Equal("verify", rows[39]["operation"])
+
+	resp = get("/jobs/1/results?format=csv", nil)
+	assert.Equal("text/csv", resp.Header.Get("Content-Type"))
+	records, err := csv.NewReader(resp.Body).ReadAll()
+	assert.NoError(err)
+	resp.Body.Close()
+	assert.Len(records, 41)
+	assert.Equal(util.BenchDataCircuit{}.Headers(), records[0])
+
+	// the queued jobs run one after the other
+	var statuses []JobStatus
+	assert.Eventually(func() bool {
+		get("/jobs", &statuses)
+		return statuses[2].Status == StatusDone
+	}, time.Minute, time.Millisecond)
+	assert.Len(statuses, 3)
+	for _, status := range statuses {
+		assert.Equal(StatusDone, status.Status)
+	}
+	_, maxRunning := runner.count()
+	assert.Equal(1, maxRunning)
+}
+
+func TestSpecRuns(t *testing.T) {
+	assert := test.NewAssert(t)
+
+	var s Spec
+	assert.NoError(json.Unmarshal([]byte(strings.Replace(spec, `"prove", "verify"`, `"prove", "serialize", "export-solidity"`, 1)), &s))
+	runs, skipped, err := s.Runs(writeInputs(t))
+	assert.NoError(err)
+
+	// the algorithms of the circuit schema only: per backend, the 2 cubic inputs over both curves and
+	// the mimc input over bn254, mimc having no witness over bls24_317, count times
+	assert.Len(runs, 2*(2*2+1)*2)
+	for _, run := range runs {
+		assert.Equal("prove", run.Algo)
+	}
+	assert.Contains(skipped, "groth16 cubic serialize bn254: invalid algo, must be compile, setup, witness, prove or verify")
+	assert.Contains(skipped, "plonk mimc export-solidity bn254: invalid algo, must be compile, setup, witness, prove or verify")
+	assert.Contains(skipped, "plonk mimc prove bls24_317: the mimc witness is not implemented over bls24_317")
+}
+
+func TestInputFiles(t *testing.T) {
+	assert := test.NewAssert(t)
+
+	root := writeInputs(t)
+	files, err := inputFiles(root, "input/circuit/cubic")
+	assert.NoError(err)
+	assert.Equal([]string{"input/circuit/cubic/input_1.json", "input/circuit/cubic/input_2.json"}, files)
+	files, err = inputFiles(root, "input/circuit/mimc/../cubic/input_2.json")
+	assert.NoError(err)
+	assert.Equal([]string{"input/circuit/mimc/../cubic/input_2.json"}, files)
+
+	// the inputs of another tree exist, but are out of root
+	other := writeInputs(t)
+	for _, path := range []string{
+		"",
+		"input/circuit/missing",
+		"input/circuit/mimc/input",
+		filepath.Join(other, "input/circuit/mimc/input.json"),
+		filepath.Join(root, "input/circuit/mimc/input.json"),
+		filepath.Join("..", filepath.Base(other), "input/circuit/mimc/input.json"),
+		"input/../../" + filepath.Base(other) + "/input/circuit/cubic",
+	} {
+		_, err := inputFiles(root, path)
+		assert.Error(err, path)
+	}
+}
+
+// writeInputs writes the input files of the spec in a temporary directory and returns it
+func writeInputs(t *testing.T) string {
+	root := t.TempDir()
+	for _, input := range []string{"input/circuit/cubic/input_1.json", "input/circuit/cubic/input_2.json", "input/circuit/mimc/input.json"} {
+		if err := os.MkdirAll(filepath.Join(root, filepath.Dir(input)), 0755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(filepath.Join(root, input), []byte("{}"), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	return root
+}
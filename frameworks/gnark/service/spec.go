@@ -0,0 +1,219 @@
+package service
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/consensys/gnark-crypto/ecc"
+	"github.com/zkCollective/zk-Harness/frameworks/gnark/parser"
+	"github.com/zkCollective/zk-Harness/frameworks/gnark/util"
+)
+
+// Algos are the algorithms of the circuit schema, the OPERATIONS of src/reader/process_circuit.py, whose
+// results are the records of the groth16 and plonk commands
+var Algos = []string{"compile", "setup", "witness", "prove", "verify"}
+
+// Spec is a benchmark specification, with the schema of the configurations in input/config/gnark
+type Spec struct {
+	Project    string  `json:"project"`
+	ProjectURL string  `json:"project_url"`
+	Category   string  `json:"category"`
+	Count      int     `json:"count"`
+	Payload    Payload `json:"payload"`
+}
+
+// Payload lists the backends, curves, circuits and algorithms whose every combination is benchmarked
+type Payload struct {
+	Backend   []string               `json:"backend"`
+	Curves    []string               `json:"curves"`
+	Circuits  map[string]CircuitSpec `json:"circuits"`
+	Algorithm []string               `json:"algorithm"`
+	Custom    json.RawMessage        `json:"custom,omitempty"`
+}
+
+// CircuitSpec holds the inputs of a circuit, each a JSON file or a directory of JSON files
+type CircuitSpec struct {
+	InputPath InputPaths `json:"input_path"`
+}
+
+// InputPaths are the input paths of a circuit, given as a single path or a list
+type InputPaths []string
+
+func (p *InputPaths) UnmarshalJSON(b []byte) error {
+	var path string
+	if err := json.Unmarshal(b, &path); err == nil {
+		*p = InputPaths{path}
+		return nil
+	}
+	return json.Unmarshal(b, (*[]string)(p))
+}
+
+// Run is a single benchmark of a spec, run by the groth16 or plonk command
+type Run struct {
+	Backend string `json:"backend"`
+	Curve   string `json:"curve"`
+	Circuit string `json:"circuit"`
+	Input   string `json:"input"`
+	Algo    string `json:"algo"`
+}
+
+// Args returns the arguments of the harness running the benchmark once, appending its results to outputPath
+func (r Run) Args(outputPath string) []string {
+	return []string{
+		r.Backend,
+		"--circuit=" + r.Circuit,
+		"--algo=" + r.Algo,
+		"--curve=" + r.Curve,
+		"--input=" + r.Input,
+		"--count=1",
+		"--outputPath=" + outputPath,
+	}
+}
+
+// Header returns the header of the records the run writes to its output, the one of the circuit schema,
+// or nil if the algorithm is outside the schema
+func (r Run) Header() []string {
+	if !slices.Contains(Algos, r.Algo) {
+		return nil
+	}
+	return util.BenchDataCircuit{}.Headers()
+}
+
+func (r Run) String() string {
+	return strings.Join(r.Args("")[:5], " ")
+}
+
+// Runs expands the spec into its runs, in the order of the python harness which repeats every run count
+// times, and returns the combinations left out with their reason. The input paths are relative to root.
+func (s *Spec) Runs(root string) ([]Run, []string, error) {
+	if s.Category != "circuit" {
+		return nil, nil, errors.New("only the circuit category is served")
+	}
+	if s.Count <= 0 {
+		return nil, nil, errors.New("count must be >= 1")
+	}
+	p := s.Payload
+	if len(p.Backend) == 0 || len(p.Curves) == 0 || len(p.Circuits) == 0 || len(p.Algorithm) == 0 {
+		return nil, nil, errors.New("the payload needs a backend, a curve, a circuit and an algorithm")
+	}
+
+	circuits := make([]string, 0, len(p.Circuits))
+	for name := range p.Circuits {
+		circuits = append(circuits, name)
+	}
+	sort.Strings(circuits)
+	inputs := make(map[string][]string)
+	for _, name := range circuits {
+		for _, path := range p.Circuits[name].InputPath {
+			files, err := inputFiles(root, path)
+			if err != nil {
+				return nil, nil, fmt.Errorf("%s: %w", name, err)
+			}
+			inputs[name] = append(inputs[name], files...)
+		}
+	}
+
+	var (
+		runs    []Run
+		skipped []string
+	)
+	for _, backend := range p.Backend {
+		for _, curve := range p.Curves {
+			curveID, ok := curveByName(curve)
+			for _, circuit := range circuits {
+				for _, algo := range p.Algorithm {
+					cell := backend + " " + circuit + " " + algo + " " + curve
+					var err error
+					switch {
+					case !ok:
+						err = errors.New("invalid curve")
+					case !slices.Contains(Algos, algo):
+						err = errors.New("invalid algo, must be compile, setup, witness, prove or verify")
+					default:
+						err = parser.Support(circuit, backend, curveID, algo)
+					}
+					if err != nil {
+						skipped = append(skipped, cell+": "+err.Error())
+						continue
+					}
+					for _, input := range inputs[circuit] {
+						for i := 0; i < s.Count; i++ {
+							runs = append(runs, Run{Backend: backend, Curve: curve, Circuit: circuit, Input: input, Algo: algo})
+						}
+					}
+				}
+			}
+		}
+	}
+	if len(runs) == 0 {
+		return nil, skipped, errors.New("no supported benchmark in the spec")
+	}
+	return runs, skipped, nil
+}
+
+// inputFiles returns the input path if it is a JSON file or the JSON files of the directory, relative to root.
+// The path comes from the spec of a client and must not leave root
+func inputFiles(root string, path string) ([]string, error) {
+	if filepath.IsAbs(path) || !filepath.IsLocal(path) {
+		return nil, fmt.Errorf("input %s is not a relative path in the input tree", path)
+	}
+	info, err := os.Stat(filepath.Join(root, path))
+	if err != nil {
+		return nil, fmt.Errorf("input %s does not exist", path)
+	}
+	if !info.IsDir() {
+		if filepath.Ext(path) != ".json" {
+			return nil, fmt.Errorf("input %s is not a JSON file", path)
+		}
+		return []string{path}, nil
+	}
+
+	entries, err := os.ReadDir(filepath.Join(root, path))
+	if err != nil {
+		return nil, err
+	}
+	var files []string
+	for _, e := range entries {
+		if !e.IsDir() && filepath.Ext(e.Name()) == ".json" {
+			files = append(files, filepath.Join(path, e.Name()))
+		}
+	}
+	if len(files) == 0 {
+		return nil, fmt.Errorf("no input file in %s", path)
+	}
+	// input_10.json after input_2.json
+	sort.Slice(files, func(i, j int) bool {
+		a, b := inputSize(files[i]), inputSize(files[j])
+		if a != b {
+			return a < b
+		}
+		return files[i] < files[j]
+	})
+	return files, nil
+}
+
+// inputSize returns the number ending the name of an input file, e.g. 10 for input_10.json, or -1
+func inputSize(path string) int {
+	name := strings.TrimSuffix(filepath.Base(path), ".json")
+	n, err := strconv.Atoi(name[strings.LastIndex(name, "_")+1:])
+	if err != nil {
+		return -1
+	}
+	return n
+}
+
+func curveByName(name string) (ecc.ID, bool) {
+	for _, id := range ecc.Implemented() {
+		if name == strings.ToLower(id.String()) {
+			return id, true
+		}
+	}
+	return ecc.UNKNOWN, false
+}
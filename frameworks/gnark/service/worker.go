@@ -0,0 +1,194 @@
+package service
+
+import (
+	"bytes"
+	"context"
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/consensys/gnark/logger"
+	"github.com/zkCollective/zk-Harness/frameworks/gnark/util"
+)
+
+// Registration answers the registration of a worker, which renews its leases at the heartbeat interval
+type Registration struct {
+	ID        string        `json:"id"`
+	Heartbeat time.Duration `json:"heartbeat"`
+}
+
+// errStatus is the unexpected status code answered by the coordinator
+type errStatus struct {
+	code int
+	msg  string
+}
+
+func (err errStatus) Error() string {
+	return fmt.Sprintf("coordinator answered %d: %s", err.code, err.msg)
+}
+
+// Worker registers its host to a coordinator, then pulls cells and runs them one at a time, pushing back
+// their results
+type Worker struct {
+	coordinator string
+	host        util.Host
+	runner      Runner
+	dir         string
+	poll        time.Duration
+	client      *http.Client
+}
+
+// NewWorker returns a worker of the coordinator at the URL, running the cells with runner into CSVs in dir
+// and asking for a cell every poll interval while none is left
+func NewWorker(coordinator string, host util.Host, runner Runner, dir string, poll time.Duration) *Worker {
+	return &Worker{
+		coordinator: strings.TrimSuffix(coordinator, "/"),
+		host:        host,
+		runner:      runner,
+		dir:         dir,
+		poll:        poll,
+		client:      &http.Client{},
+	}
+}
+
+// Run runs cells until the context is done, registering again when the coordinator forgot the worker,
+// e.g. as it restarted
+func (w *Worker) Run(ctx context.Context) error {
+	log := logger.Logger()
+	if err := os.MkdirAll(w.dir, 0755); err != nil {
+		return err
+	}
+
+	var registration Registration
+	for ctx.Err() == nil {
+		if registration.ID == "" {
+			if err := w.post(ctx, "/workers", w.host, &registration); err != nil {
+				log.Warn().Err(err).Msg("registering to " + w.coordinator)
+				w.wait(ctx)
+				continue
+			}
+			log.Info().Str("worker", registration.ID).Msg("registered to " + w.coordinator)
+		}
+
+		var cell Cell
+		err := w.post(ctx, "/workers/"+registration.ID+"/lease", nil, &cell)
+		var status errStatus
+		switch {
+		case errors.As(err, &status) && status.code == http.StatusNotFound:
+			registration.ID = ""
+			continue
+		case errors.Is(err, errNoCell):
+			w.wait(ctx)
+			continue
+		case err != nil:
+			log.Warn().Err(err).Msg("leasing a cell")
+			w.wait(ctx)
+			continue
+		}
+
+		log.Info().Str("job", cell.Job).Int("cell", cell.ID).Msg("running " + cell.Run.String())
+		result := w.runCell(ctx, registration, cell)
+		if ctx.Err() != nil {
+			break
+		}
+		if err := w.post(ctx, "/workers/"+registration.ID+"/results", result, nil); err != nil {
+			log.Warn().Err(err).Str("job", cell.Job).Int("cell", cell.ID).Msg("pushing the result")
+		}
+	}
+	return ctx.Err()
+}
+
+// runCell runs the cell into its own CSV, renewing the lease meanwhile, and reads back its results
+func (w *Worker) runCell(ctx context.Context, registration Registration, cell Cell) CellResult {
+	result := CellResult{Job: cell.Job, Cell: cell.ID}
+	path := filepath.Join(w.dir, fmt.Sprintf("%s_%d.csv", cell.Job, cell.ID))
+	os.Remove(path)
+	defer os.Remove(path)
+
+	done := make(chan struct{})
+	defer close(done)
+	go func() {
+		ticker := time.NewTicker(registration.Heartbeat)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-done:
+				return
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+				w.post(ctx, "/workers/"+registration.ID+"/heartbeat", nil, nil)
+			}
+		}
+	}()
+
+	var log bytes.Buffer
+	err := w.runner(ctx, cell.Run, path, &log)
+	result.Log = log.String()
+	if err == nil {
+		var b []byte
+		b, err = os.ReadFile(path)
+		if errors.Is(err, fs.ErrNotExist) {
+			err = errors.New("the run wrote no result")
+		}
+		if err == nil {
+			result.Records, err = csv.NewReader(bytes.NewReader(b)).ReadAll()
+		}
+	}
+	if err != nil {
+		result.Error = err.Error()
+	}
+	return result
+}
+
+// errNoCell is returned when the coordinator has no cell left to lease
+var errNoCell = errors.New("no cell left")
+
+// post posts the request in JSON to the coordinator and decodes the answer into v, if any
+func (w *Worker) post(ctx context.Context, path string, request interface{}, v interface{}) error {
+	var body io.Reader = http.NoBody
+	if request != nil {
+		b, err := json.Marshal(request)
+		if err != nil {
+			return err
+		}
+		body = bytes.NewReader(b)
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, w.coordinator+path, body)
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	resp, err := w.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	switch {
+	case resp.StatusCode == http.StatusNoContent && v != nil:
+		return errNoCell
+	case resp.StatusCode >= 300:
+		msg, _ := io.ReadAll(resp.Body)
+		return errStatus{code: resp.StatusCode, msg: strings.TrimSpace(string(msg))}
+	case v != nil:
+		return json.NewDecoder(resp.Body).Decode(v)
+	}
+	return nil
+}
+
+// wait waits for the poll interval or the end of the context
+func (w *Worker) wait(ctx context.Context) {
+	select {
+	case <-ctx.Done():
+	case <-time.After(w.poll):
+	}
+}
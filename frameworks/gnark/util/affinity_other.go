@@ -0,0 +1,15 @@
+//go:build !linux
+
+package util
+
+import "errors"
+
+// pinProcess is only supported on Linux
+func pinProcess(cpus []int) error {
+	return errors.New("pinning to a cpu set is only supported on linux")
+}
+
+// processAffinity is only supported on Linux, where the process is never pinned
+func processAffinity() ([]int, error) {
+	return nil, nil
+}
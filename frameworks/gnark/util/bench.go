@@ -1,24 +1,39 @@
 package util
 
 import (
+	"time"
+
 	"github.com/consensys/gnark/constraint"
 
 	"github.com/consensys/gnark-crypto/ecc"
-	"github.com/consensys/gnark/backend/groth16"
-	"github.com/consensys/gnark/frontend"
+	"github.com/consensys/gnark/backend"
+	"github.com/consensys/gnark/backend/witness"
 )
 
-// These Options are used for recursive Groth16 verifier
+// These Options are used for the recursive Groth16 and PlonK verifiers
 type BenchOption func(opt *BenchConfig) error
 
 type BenchConfig struct {
 	InputPath    string
-	Proof        groth16.Proof
-	VerifyingKey groth16.VerifyingKey
-	Witness      frontend.Variable
+	Proof        interface{}
+	VerifyingKey interface{}
+	Witness      witness.Witness
+	Proofs       []interface{}
+	Witnesses    []witness.Witness
 	CCS          constraint.ConstraintSystem
 	InnerCurve   ecc.ID
 	OuterCurve   ecc.ID
+	WritePhase   PhaseWriteFunction
+	MemTimeline  time.Duration
+	WriteMemory  TimelineWriteFunction
+	WriteSerial  SerializationWriteFunction
+	SRSPath      string
+	WriteSRS     PhaseWriteFunction
+	KeysDir      string
+	SolidityPath string
+	WriteGas     GasWriteFunction
+	ProverOpts   []backend.ProverOption
+	VerifierOpts []backend.VerifierOption
 }
 
 // Optionally provide input path to Witness def
@@ -29,27 +44,38 @@ func WithInput(inputPath string) BenchOption {
 	}
 }
 
-func WithProof(proof groth16.Proof) BenchOption {
+// WithProof sets the inner proof, a groth16.Proof or a plonk.Proof
+func WithProof(proof interface{}) BenchOption {
 	return func(opt *BenchConfig) error {
 		opt.Proof = proof
 		return nil
 	}
 }
 
-func WithVK(verifyingKey groth16.VerifyingKey) BenchOption {
+// WithVK sets the inner verifying key, a groth16.VerifyingKey or a plonk.VerifyingKey
+func WithVK(verifyingKey interface{}) BenchOption {
 	return func(opt *BenchConfig) error {
 		opt.VerifyingKey = verifyingKey
 		return nil
 	}
 }
 
-func WithWitness(witness frontend.Variable) BenchOption {
+func WithWitness(witness witness.Witness) BenchOption {
 	return func(opt *BenchConfig) error {
 		opt.Witness = witness
 		return nil
 	}
 }
 
+// WithProofs sets the inner proofs and witnesses of the aggregation circuits
+func WithProofs(proofs []interface{}, witnesses []witness.Witness) BenchOption {
+	return func(opt *BenchConfig) error {
+		opt.Proofs = proofs
+		opt.Witnesses = witnesses
+		return nil
+	}
+}
+
 func WithInnerCCS(ccs constraint.ConstraintSystem) BenchOption {
 	return func(opt *BenchConfig) error {
 		opt.CCS = ccs
@@ -70,3 +96,63 @@ func WithOuterCurve(outerCurve ecc.ID) BenchOption {
 		return nil
 	}
 }
+
+// Optionally report the sub-phase breakdown of proof generation
+func WithPhases(fnWritePhase PhaseWriteFunction) BenchOption {
+	return func(opt *BenchConfig) error {
+		opt.WritePhase = fnWritePhase
+		return nil
+	}
+}
+
+// Optionally sample the memory over the timed region every interval
+func WithMemTimeline(interval time.Duration, fnWriteMemory TimelineWriteFunction) BenchOption {
+	return func(opt *BenchConfig) error {
+		opt.MemTimeline = interval
+		opt.WriteMemory = fnWriteMemory
+		return nil
+	}
+}
+
+// Optionally report the serialization of every object with algo serialize
+func WithSerialization(fnWriteSerial SerializationWriteFunction) BenchOption {
+	return func(opt *BenchConfig) error {
+		opt.WriteSerial = fnWriteSerial
+		return nil
+	}
+}
+
+// Optionally load the PlonK SRS from the file at srsPath, reporting the time of its steps with algo setup
+func WithSRS(srsPath string, fnWriteSRS PhaseWriteFunction) BenchOption {
+	return func(opt *BenchConfig) error {
+		opt.SRSPath = srsPath
+		opt.WriteSRS = fnWriteSRS
+		return nil
+	}
+}
+
+// Optionally read the Groth16 keys from the directory instead of running the setup
+func WithKeys(keysDir string) BenchOption {
+	return func(opt *BenchConfig) error {
+		opt.KeysDir = keysDir
+		return nil
+	}
+}
+
+// Optionally export the Solidity verifier to solidityPath with algo export-solidity, reporting its gas if solc is found
+func WithSolidity(solidityPath string, fnWriteGas GasWriteFunction) BenchOption {
+	return func(opt *BenchConfig) error {
+		opt.SolidityPath = solidityPath
+		opt.WriteGas = fnWriteGas
+		return nil
+	}
+}
+
+// Optionally prove and verify with the options of the backend instead of the defaults of gnark
+func WithBackendOptions(options BackendOptions, curveID ecc.ID) BenchOption {
+	return func(opt *BenchConfig) error {
+		opt.ProverOpts = options.ProverOptions(curveID)
+		opt.VerifierOpts = options.VerifierOptions(curveID)
+		return nil
+	}
+}
@@ -0,0 +1,27 @@
+package util
+
+import (
+	"os"
+	"os/exec"
+	"time"
+)
+
+// RunChild runs the command in a fresh child process, forwarding its output, and returns the peak
+// resident set size of the child in kilobytes, as reported by wait4, and its wall-clock time
+func RunChild(name string, args ...string) (uint64, time.Duration, error) {
+	cmd := exec.Command(name, args...)
+	cmd.Stdout = os.Stdout
+	cmd.Stderr = os.Stderr
+
+	start := time.Now()
+	if err := cmd.Run(); err != nil {
+		return 0, 0, err
+	}
+	took := time.Since(start)
+
+	maxRSS, err := maxRSS(cmd.ProcessState)
+	if err != nil {
+		return 0, 0, err
+	}
+	return maxRSS, took, nil
+}
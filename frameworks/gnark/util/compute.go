@@ -83,6 +83,17 @@ func PreCalcMIMC(curveID ecc.ID, preImage frontend.Variable) interface{} {
 		expectedh := goMimc.Sum(nil)
 		return expectedh
 
+	case ecc.BLS12_381:
+		// compute expected Y
+		var expectedY bls12381fr.Element
+		expectedY.SetInterface(preImage)
+
+		// running MiMC (Go)
+		goMimc := hash.MIMC_BLS12_381.New()
+		goMimc.Write(expectedY.Marshal())
+		expectedh := goMimc.Sum(nil)
+		return expectedh
+
 	case ecc.BLS12_377:
 		// compute expected Y
 		var expectedY bls12377fr.Element
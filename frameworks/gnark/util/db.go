@@ -0,0 +1,382 @@
+package util
+
+import (
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math"
+	"os"
+	"regexp"
+	"slices"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"golang.org/x/mod/semver"
+	_ "modernc.org/sqlite"
+)
+
+// schema of the results database: a run is an invocation of the harness, or a campaign of invocations
+// sharing a run id, on a host; its results are the rows of the CSVs as JSON, with the time of every
+// iteration averaged by their time column
+const schema = `
+CREATE TABLE IF NOT EXISTS runs (
+	id                TEXT PRIMARY KEY,
+	started           TEXT NOT NULL,
+	command           TEXT NOT NULL,
+	host              TEXT NOT NULL,
+	os                TEXT NOT NULL,
+	arch              TEXT NOT NULL,
+	hostCPU           TEXT NOT NULL,
+	hostPhysicalCores INTEGER NOT NULL,
+	hostLogicalCores  INTEGER NOT NULL,
+	goVersion         TEXT NOT NULL,
+	gnark             TEXT NOT NULL,
+	gnarkCrypto       TEXT NOT NULL,
+	revision          TEXT NOT NULL
+);
+CREATE TABLE IF NOT EXISTS results (
+	id      INTEGER PRIMARY KEY AUTOINCREMENT,
+	run     TEXT NOT NULL REFERENCES runs(id),
+	created TEXT NOT NULL,
+	columns TEXT NOT NULL,
+	data    TEXT NOT NULL
+);
+CREATE TABLE IF NOT EXISTS samples (
+	result    INTEGER NOT NULL REFERENCES results(id),
+	iteration INTEGER NOT NULL,
+	time      INTEGER NOT NULL,
+	PRIMARY KEY (result, iteration)
+);
+CREATE INDEX IF NOT EXISTS results_run ON results(run);
+`
+
+// runColumns are the columns of a run prepended to its results, selected by their name in a query
+var runColumns = []string{"run", "created", "host", "os", "arch", "hostCPU", "hostPhysicalCores", "hostLogicalCores", "goVersion", "gnark", "gnarkCrypto", "revision"}
+
+// aggregateColumns are appended to the columns grouped by a query, over the times in microseconds
+var aggregateColumns = []string{"results", "samples", "mean", "median", "min", "max", "stddev"}
+
+// DB is a SQLite database of results
+type DB struct {
+	db *sql.DB
+}
+
+// OpenDB opens the database at path, creating it if needed. Several harnesses may write to it at once.
+func OpenDB(path string) (*DB, error) {
+	db, err := sql.Open("sqlite", "file:"+path+"?_pragma=busy_timeout(10000)")
+	if err != nil {
+		return nil, err
+	}
+	if _, err := db.Exec(schema); err != nil {
+		db.Close()
+		return nil, fmt.Errorf("%s: %w", path, err)
+	}
+	return &DB{db: db}, nil
+}
+
+func (d *DB) Close() error {
+	return d.db.Close()
+}
+
+// AddRun registers the run of the command on the host, unless a run with the id is already registered
+func (d *DB) AddRun(id string, host Host, command string, started time.Time) error {
+	_, err := d.db.Exec(`INSERT OR IGNORE INTO runs VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)`,
+		id, started.UTC().Format(time.RFC3339), command, host.Name, host.OS, host.Arch, host.CPU,
+		host.NbPhysicalCores, host.NbLogicalCores, host.GoVersion, host.Gnark, host.GnarkCrypto, host.Revision)
+	return err
+}
+
+// AddResult stores a result of the run, a row of a CSV, with the times of the iterations it averages
+func (d *DB) AddResult(run string, data interface{}, samples []time.Duration) error {
+	headers, ok := data.(HeadersProvider)
+	if !ok {
+		return errors.New("the result has no headers")
+	}
+	columns, values := headers.Headers(), data.(ValuesProvider).Values()
+	row := make(map[string]string, len(columns))
+	for i, column := range columns {
+		row[column] = values[i]
+	}
+	bColumns, err := json.Marshal(columns)
+	if err != nil {
+		return err
+	}
+	bRow, err := json.Marshal(row)
+	if err != nil {
+		return err
+	}
+
+	tx, err := d.db.Begin()
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+	res, err := tx.Exec(`INSERT INTO results (run, created, columns, data) VALUES (?, ?, ?, ?)`,
+		run, time.Now().UTC().Format(time.RFC3339Nano), string(bColumns), string(bRow))
+	if err != nil {
+		return err
+	}
+	id, err := res.LastInsertId()
+	if err != nil {
+		return err
+	}
+	for i, took := range samples {
+		if _, err := tx.Exec(`INSERT INTO samples VALUES (?, ?, ?)`, id, i, took.Microseconds()); err != nil {
+			return err
+		}
+	}
+	return tx.Commit()
+}
+
+// Query selects results of a database
+type Query struct {
+	// Where maps a column, of the run or of the results, to the value it must have, ignoring the case
+	Where map[string]string
+	// LastVersions, if positive, keeps the results of the last versions of gnark, ordered by version
+	LastVersions int
+	// GroupBy, if set, aggregates the times of the results by these columns
+	GroupBy []string
+}
+
+// column names a column of the results in the JSON path of json_extract
+var column = regexp.MustCompile(`^[A-Za-z0-9_]+$`)
+
+// Query returns the results as the records of a CSV, the first one being the header. The results have
+// the columns of their run, the union of their own columns and their samples, separated by ";". Grouped
+// results have the columns grouped by and the aggregates of the times of their samples, or of the time
+// of the results without samples.
+func (d *DB) Query(q Query) ([][]string, error) {
+	var (
+		conditions []string
+		args       []interface{}
+	)
+	keys := make([]string, 0, len(q.Where))
+	for key := range q.Where {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	for _, key := range keys {
+		if !column.MatchString(key) {
+			return nil, fmt.Errorf("invalid column %q", key)
+		}
+		conditions = append(conditions, "lower("+sqlColumn(key)+") = lower(?)")
+		args = append(args, q.Where[key])
+	}
+	query := `SELECT runs.id, results.created, runs.host, runs.os, runs.arch, runs.hostCPU,
+		runs.hostPhysicalCores, runs.hostLogicalCores, runs.goVersion, runs.gnark, runs.gnarkCrypto,
+		runs.revision, results.columns, results.data,
+		(SELECT group_concat(time, ';' ORDER BY iteration) FROM samples WHERE result = results.id)
+		FROM results JOIN runs ON results.run = runs.id`
+	if len(conditions) > 0 {
+		query += " WHERE " + strings.Join(conditions, " AND ")
+	}
+	rows, err := d.db.Query(query+" ORDER BY results.id", args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	type result struct {
+		run     []string
+		columns []string
+		data    map[string]string
+		samples string
+	}
+	var results []result
+	for rows.Next() {
+		var (
+			r             result
+			columns, data string
+			samples       sql.NullString
+		)
+		r.run = make([]string, len(runColumns))
+		dest := make([]interface{}, 0, len(runColumns)+3)
+		for i := range r.run {
+			dest = append(dest, &r.run[i])
+		}
+		if err := rows.Scan(append(dest, &columns, &data, &samples)...); err != nil {
+			return nil, err
+		}
+		if err := json.Unmarshal([]byte(columns), &r.columns); err != nil {
+			return nil, err
+		}
+		if err := json.Unmarshal([]byte(data), &r.data); err != nil {
+			return nil, err
+		}
+		r.samples = samples.String
+		results = append(results, r)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	// the results of the last versions, ordered by version
+	if q.LastVersions > 0 {
+		gnark := slices.Index(runColumns, "gnark")
+		var versions []string
+		for _, r := range results {
+			if !slices.Contains(versions, r.run[gnark]) {
+				versions = append(versions, r.run[gnark])
+			}
+		}
+		sort.SliceStable(versions, func(i, j int) bool { return semver.Compare(versions[i], versions[j]) < 0 })
+		versions = versions[max(0, len(versions)-q.LastVersions):]
+		kept := results[:0]
+		for _, r := range results {
+			if slices.Contains(versions, r.run[gnark]) {
+				kept = append(kept, r)
+			}
+		}
+		results = kept
+		sort.SliceStable(results, func(i, j int) bool {
+			return semver.Compare(results[i].run[gnark], results[j].run[gnark]) < 0
+		})
+	}
+
+	value := func(r result, column string) string {
+		for i, c := range runColumns {
+			if c == column {
+				return r.run[i]
+			}
+		}
+		return r.data[column]
+	}
+
+	if len(q.GroupBy) == 0 {
+		header := append([]string(nil), runColumns...)
+		for _, r := range results {
+			for _, c := range r.columns {
+				if !slices.Contains(header, c) {
+					header = append(header, c)
+				}
+			}
+		}
+		header = append(header, "samples")
+		records := [][]string{header}
+		for _, r := range results {
+			record := make([]string, len(header))
+			for i, c := range header[:len(header)-1] {
+				record[i] = value(r, c)
+			}
+			record[len(header)-1] = r.samples
+			records = append(records, record)
+		}
+		return records, nil
+	}
+
+	type group struct {
+		key     []string
+		results int
+		times   []float64
+	}
+	var groups []*group
+	byKey := make(map[string]*group)
+	for _, r := range results {
+		key := make([]string, len(q.GroupBy))
+		for i, c := range q.GroupBy {
+			key[i] = value(r, c)
+		}
+		g, ok := byKey[strings.Join(key, "\x00")]
+		if !ok {
+			g = &group{key: key}
+			byKey[strings.Join(key, "\x00")] = g
+			groups = append(groups, g)
+		}
+		times := strings.Split(r.samples, ";")
+		if r.samples == "" {
+			times = []string{r.data["time"]}
+		}
+		for _, t := range times {
+			took, err := strconv.ParseFloat(t, 64)
+			if err != nil {
+				return nil, fmt.Errorf("result of run %s without time", r.run[0])
+			}
+			g.times = append(g.times, took)
+		}
+		g.results++
+	}
+
+	records := [][]string{append(append([]string(nil), q.GroupBy...), aggregateColumns...)}
+	for _, g := range groups {
+		s := stats(g.times)
+		records = append(records, append(append([]string(nil), g.key...),
+			strconv.Itoa(g.results), strconv.Itoa(len(g.times)), formatFloat(s.mean), formatFloat(s.median),
+			formatFloat(s.min), formatFloat(s.max), formatFloat(s.stdDev)))
+	}
+	return records, nil
+}
+
+// statistics of a sample
+type statistics struct {
+	mean, median, min, max, stdDev float64
+}
+
+// stats returns the statistics of a non-empty sample, its standard deviation being the sample one
+func stats(sample []float64) statistics {
+	sorted := append([]float64(nil), sample...)
+	sort.Float64s(sorted)
+	n := len(sorted)
+	s := statistics{min: sorted[0], max: sorted[n-1], median: sorted[n/2]}
+	if n%2 == 0 {
+		s.median = (sorted[n/2-1] + sorted[n/2]) / 2
+	}
+	for _, v := range sorted {
+		s.mean += v
+	}
+	s.mean /= float64(n)
+	if n > 1 {
+		for _, v := range sorted {
+			s.stdDev += (v - s.mean) * (v - s.mean)
+		}
+		s.stdDev = math.Sqrt(s.stdDev / float64(n-1))
+	}
+	return s
+}
+
+// DBSink stores the results in the database at path, opened with the first result, under a run
+type DBSink struct {
+	path string
+	run  string
+	db   *DB
+}
+
+// NewDBSink returns a sink registering the run with the id when it stores its first result
+func NewDBSink(path string, run string) *DBSink {
+	return &DBSink{path: path, run: run}
+}
+
+func (s *DBSink) Store(data interface{}, samples []time.Duration) error {
+	if s.db == nil {
+		db, err := OpenDB(s.path)
+		if err != nil {
+			return err
+		}
+		if err := db.AddRun(s.run, DescribeHost(), strings.Join(os.Args, " "), time.Now()); err != nil {
+			db.Close()
+			return err
+		}
+		s.db = db
+	}
+	return s.db.AddResult(s.run, data, samples)
+}
+
+// sqlColumn returns the SQL expression of a column of a run or of the results
+func sqlColumn(name string) string {
+	switch name {
+	case "run":
+		return "runs.id"
+	case "created":
+		return "results.created"
+	}
+	if slices.Contains(runColumns, name) {
+		return "runs." + name
+	}
+	return `json_extract(results.data, '$."` + name + `"')`
+}
+
+func formatFloat(v float64) string {
+	return strconv.FormatFloat(v, 'f', 1, 64)
+}
@@ -0,0 +1,71 @@
+package util
+
+import (
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/consensys/gnark/test"
+)
+
+func TestDB(t *testing.T) {
+	assert := test.NewAssert(t)
+
+	path := filepath.Join(t.TempDir(), "results.sqlite")
+	result := func(circuit string, took int64) BenchDataCircuit {
+		return BenchDataCircuit{Framework: "gnark", Category: "circuit", Backend: "groth16", Curve: "BN254", Circuit: circuit, Operation: "prove", Count: 2, RunTime: took}
+	}
+
+	// a run per version of gnark, the results of the first one written through the sink
+	versions := []string{"v0.9.1", "v0.10.0", "v0.9.0"}
+	for i, version := range versions {
+		db, err := OpenDB(path)
+		assert.NoError(err)
+		host := Host{Name: "host", Gnark: version}
+		assert.NoError(db.AddRun(version, host, "gnark groth16", time.Now()))
+		assert.NoError(db.AddRun(version, Host{Name: "other"}, "gnark plonk", time.Now()), "run registered once")
+		if i > 0 {
+			assert.NoError(db.AddResult(version, result("sha2", 10*int64(i)), nil))
+			assert.NoError(db.Close())
+			continue
+		}
+		assert.NoError(db.Close())
+
+		Sink = NewDBSink(path, version)
+		AddSample(time.Millisecond)
+		AddSample(3 * time.Millisecond)
+		assert.NoError(WriteData("csv", result("sha2", 2000), filepath.Join(t.TempDir(), "results.csv")))
+		assert.NoError(WriteData("csv", result("mimc", 1), filepath.Join(t.TempDir(), "results.csv")))
+		Sink = nil
+	}
+
+	db, err := OpenDB(path)
+	assert.NoError(err)
+	defer db.Close()
+
+	records, err := db.Query(Query{Where: map[string]string{"circuit": "sha2", "curve": "bn254"}})
+	assert.NoError(err)
+	assert.Len(records, 4)
+	header := records[0]
+	assert.Equal(append(append(append([]string(nil), runColumns...), BenchDataCircuit{}.Headers()...), "samples"), header)
+	assert.Equal("v0.9.1", records[1][0])
+	assert.Equal("host", records[1][2])
+	assert.Equal("1000;3000", records[1][len(header)-1])
+	assert.Equal("", records[2][len(header)-1])
+
+	// the times of sha2 over the last 2 versions, ordered by version
+	records, err = db.Query(Query{Where: map[string]string{"circuit": "sha2"}, LastVersions: 2, GroupBy: []string{"gnark", "circuit"}})
+	assert.NoError(err)
+	assert.Equal([][]string{
+		{"gnark", "circuit", "results", "samples", "mean", "median", "min", "max", "stddev"},
+		{"v0.9.1", "sha2", "1", "2", "2000.0", "2000.0", "1000.0", "3000.0", "1414.2"},
+		{"v0.10.0", "sha2", "1", "1", "10.0", "10.0", "10.0", "10.0", "0.0"},
+	}, records)
+
+	records, err = db.Query(Query{GroupBy: []string{"host"}})
+	assert.NoError(err)
+	assert.Equal([]string{"host", "4", "5", "806.2", "20.0", "1.0", "3000.0", "1299.1"}, records[1])
+
+	_, err = db.Query(Query{Where: map[string]string{"circuit') OR 1=1 --": "sha2"}})
+	assert.Error(err)
+}
@@ -0,0 +1,142 @@
+package util
+
+import (
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os/exec"
+	"strings"
+
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core"
+	"github.com/ethereum/go-ethereum/core/rawdb"
+	"github.com/ethereum/go-ethereum/core/state"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/core/vm/runtime"
+	"github.com/ethereum/go-ethereum/params"
+)
+
+// evmGasLimit is the gas given to the deployment and the call, the block gas limit of mainnet
+const evmGasLimit = 30_000_000
+
+// ErrNoSolc is returned when no solc binary is found in the PATH
+var ErrNoSolc = errors.New("solc not found")
+
+// Contract is a contract compiled by solc
+type Contract struct {
+	ABI abi.ABI
+	Bin []byte
+}
+
+// GasCost is the gas used to deploy a contract and by a transaction calling it, the latter being
+// split into its calldata, the intrinsic transaction cost and the execution
+type GasCost struct {
+	Deploy    uint64
+	Call      uint64
+	Calldata  uint64
+	Execution uint64
+}
+
+// CompileSolidity compiles the contract of the given name in the file at path with the solc of the PATH
+func CompileSolidity(path string, name string) (Contract, error) {
+	solc, err := exec.LookPath("solc")
+	if err != nil {
+		return Contract{}, ErrNoSolc
+	}
+	out, err := exec.Command(solc, "--optimize", "--combined-json", "abi,bin", path).Output()
+	if err != nil {
+		var exitErr *exec.ExitError
+		if errors.As(err, &exitErr) {
+			return Contract{}, fmt.Errorf("solc: %s", exitErr.Stderr)
+		}
+		return Contract{}, err
+	}
+
+	var res struct {
+		Contracts map[string]struct {
+			ABI json.RawMessage
+			Bin string
+		}
+	}
+	if err := json.Unmarshal(out, &res); err != nil {
+		return Contract{}, err
+	}
+	for id, c := range res.Contracts {
+		if !strings.HasSuffix(id, ":"+name) {
+			continue
+		}
+		// solc before 0.8.10 writes the abi as a string
+		rawABI := c.ABI
+		var s string
+		if json.Unmarshal(c.ABI, &s) == nil {
+			rawABI = json.RawMessage(s)
+		}
+		parsed, err := abi.JSON(strings.NewReader(string(rawABI)))
+		if err != nil {
+			return Contract{}, err
+		}
+		bin, err := hex.DecodeString(c.Bin)
+		if err != nil {
+			return Contract{}, err
+		}
+		return Contract{parsed, bin}, nil
+	}
+	return Contract{}, fmt.Errorf("contract %s not found in %s", name, path)
+}
+
+// MeasureGas deploys the contract on an in-process EVM and calls its method with args, which must neither revert
+// nor return false, returning the gas the deployment and the call would use as transactions
+func MeasureGas(contract Contract, method string, args ...interface{}) (GasCost, error) {
+	input, err := contract.ABI.Pack(method, args...)
+	if err != nil {
+		return GasCost{}, fmt.Errorf("%s: %w", method, err)
+	}
+
+	// the random value of the block enables the rules since the merge, among them PUSH0 of Shanghai
+	cfg := runtime.Config{
+		ChainConfig: params.AllDevChainProtocolChanges,
+		Origin:      common.BytesToAddress([]byte("sender")),
+		GasLimit:    evmGasLimit,
+		Random:      &common.Hash{},
+	}
+	cfg.State, err = state.New(types.EmptyRootHash, state.NewDatabase(rawdb.NewMemoryDatabase()), nil)
+	if err != nil {
+		return GasCost{}, err
+	}
+
+	var cost GasCost
+	_, address, leftOver, err := runtime.Create(contract.Bin, &cfg)
+	if err != nil {
+		return GasCost{}, fmt.Errorf("deploy: %w", err)
+	}
+	intrinsic, err := core.IntrinsicGas(contract.Bin, nil, true, true, true, true)
+	if err != nil {
+		return GasCost{}, fmt.Errorf("deploy: %w", err)
+	}
+	cost.Deploy = intrinsic + evmGasLimit - leftOver
+
+	out, leftOver, err := runtime.Call(address, input, &cfg)
+	if err != nil {
+		return GasCost{}, fmt.Errorf("%s: %w", method, err)
+	}
+	// a verifier may return false rather than revert
+	res, err := contract.ABI.Unpack(method, out)
+	if err != nil {
+		return GasCost{}, fmt.Errorf("%s: %w", method, err)
+	}
+	if len(res) == 1 && res[0] == false {
+		return GasCost{}, fmt.Errorf("%s returned false", method)
+	}
+	for _, b := range input {
+		if b == 0 {
+			cost.Calldata += params.TxDataZeroGas
+		} else {
+			cost.Calldata += params.TxDataNonZeroGasEIP2028
+		}
+	}
+	cost.Execution = evmGasLimit - leftOver
+	cost.Call = params.TxGas + cost.Calldata + cost.Execution
+	return cost, nil
+}
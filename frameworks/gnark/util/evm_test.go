@@ -0,0 +1,60 @@
+package util
+
+import (
+	"errors"
+	"math/big"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/consensys/gnark/test"
+	"github.com/ethereum/go-ethereum/params"
+)
+
+const evmTestContract = `// SPDX-License-Identifier: MIT
+pragma solidity ^0.8.0;
+
+contract Sum {
+    function check(uint256[2] calldata x, uint256 sum) external pure returns (bool) {
+        return x[0] + x[1] == sum;
+    }
+}
+`
+
+func TestMeasureGas(t *testing.T) {
+	assert := test.NewAssert(t)
+
+	path := filepath.Join(t.TempDir(), "sum.sol")
+	assert.NoError(os.WriteFile(path, []byte(evmTestContract), 0o600))
+	contract, err := CompileSolidity(path, "Sum")
+	if errors.Is(err, ErrNoSolc) {
+		t.Skip("solc not found")
+	}
+	assert.NoError(err)
+
+	args := []interface{}{[2]*big.Int{big.NewInt(1), big.NewInt(2)}, big.NewInt(3)}
+	cost, err := MeasureGas(contract, "check", args...)
+	assert.NoError(err)
+	assert.True(cost.Deploy > params.TxGas, "deployment gas %d", cost.Deploy)
+	assert.True(cost.Execution > 0, "execution gas %d", cost.Execution)
+	assert.Equal(params.TxGas+cost.Calldata+cost.Execution, cost.Call)
+
+	// the selector and 3 words, of which 3 non-zero bytes
+	input, err := contract.ABI.Pack("check", args...)
+	assert.NoError(err)
+	nonZero := 3
+	for _, b := range input[:4] {
+		if b != 0 {
+			nonZero++
+		}
+	}
+	assert.Equal(uint64(nonZero)*params.TxDataNonZeroGasEIP2028+uint64(len(input)-nonZero)*params.TxDataZeroGas, cost.Calldata)
+
+	_, err = MeasureGas(contract, "check", [2]*big.Int{big.NewInt(1), big.NewInt(2)}, big.NewInt(4))
+	assert.Error(err, "false is an error")
+	_, err = MeasureGas(contract, "check", big.NewInt(3))
+	assert.Error(err, "arguments not matching the abi")
+
+	_, err = CompileSolidity(path, "Product")
+	assert.Error(err, "contract not in the file")
+}
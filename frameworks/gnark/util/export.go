@@ -0,0 +1,176 @@
+package util
+
+import (
+	"encoding/json"
+	"errors"
+	"io"
+	"math/big"
+	"reflect"
+	"strings"
+
+	"github.com/consensys/gnark-crypto/ecc"
+	"github.com/consensys/gnark/backend/witness"
+	"github.com/consensys/gnark/constraint"
+)
+
+// ExportedSystem is the JSON export of a constraint system, with the R1C of an R1CS or the gates of
+// a sparse R1CS, over wires indexed as in gnark: the public variables, the secret ones then the internal ones
+type ExportedSystem struct {
+	Curve       string         `json:"curve"`
+	Field       string         `json:"field"`
+	System      string         `json:"system"`
+	NbWires     int            `json:"nbWires"`
+	NbPublic    int            `json:"nbPublic"`
+	NbSecret    int            `json:"nbSecret"`
+	Constraints []ExportedR1C  `json:"constraints,omitempty"`
+	Gates       []ExportedGate `json:"gates,omitempty"`
+}
+
+// ExportedR1C is the constraint a⋅b == c of an R1CS, the wire 0 being the constant one
+type ExportedR1C struct {
+	A []ExportedTerm `json:"a"`
+	B []ExportedTerm `json:"b"`
+	C []ExportedTerm `json:"c"`
+}
+
+// ExportedTerm is a coefficient of a wire of a linear combination
+type ExportedTerm struct {
+	Wire  int    `json:"wire"`
+	Coeff string `json:"coeff"`
+}
+
+// ExportedGate is the gate qL⋅a + qR⋅b + qO⋅c + qM⋅a⋅b + qC == 0 of a sparse R1CS, whose rows start with
+// a gate per public wire, binding it to the public input, as in the PlonK of gnark
+type ExportedGate struct {
+	A  int    `json:"a"`
+	B  int    `json:"b"`
+	C  int    `json:"c"`
+	QL string `json:"qL"`
+	QR string `json:"qR"`
+	QO string `json:"qO"`
+	QM string `json:"qM"`
+	QC string `json:"qC"`
+}
+
+// ExportedWitness is the JSON export of the value of every wire
+type ExportedWitness struct {
+	Curve string   `json:"curve"`
+	Field string   `json:"field"`
+	Wires []string `json:"wires"`
+}
+
+// IsR1CS returns true if the constraint system is an R1CS, of Groth16, rather than a sparse R1CS of PlonK.
+// The curve specific R1CS and SparseR1CS are the same type, implementing both constraint.R1CS and
+// constraint.SparseR1CS, so the kind is read from the type of the system rather than by a type assertion
+func IsR1CS(ccs constraint.ConstraintSystem) (bool, error) {
+	system, err := coreSystem(ccs)
+	if err != nil {
+		return false, err
+	}
+	return system.Type == constraint.SystemR1CS, nil
+}
+
+// SolvedWires solves the constraint system with the witness and returns the value of every wire
+func SolvedWires(ccs constraint.ConstraintSystem, w witness.Witness) ([]*big.Int, error) {
+	isR1CS, err := IsR1CS(ccs)
+	if err != nil {
+		return nil, err
+	}
+	solution, err := ccs.Solve(w)
+	if err != nil {
+		return nil, err
+	}
+	internal, secret, public := ccs.GetNbVariables()
+	s := reflect.ValueOf(solution).Elem()
+	if isR1CS {
+		return bigInts(s.FieldByName("W")), nil
+	}
+
+	// the sparse solution holds the wires of the rows, the inputs are read from the witness
+	wires := make([]*big.Int, internal+secret+public)
+	copy(wires, bigInts(reflect.ValueOf(w.Vector())))
+	l, r, o := bigInts(s.FieldByName("L")), bigInts(s.FieldByName("R")), bigInts(s.FieldByName("O"))
+	for i, c := range ccs.(constraint.SparseR1CS).GetSparseR1Cs() {
+		wires[c.XA], wires[c.XB], wires[c.XC] = l[public+i], r[public+i], o[public+i]
+	}
+	for i := range wires {
+		if wires[i] == nil {
+			wires[i] = new(big.Int)
+		}
+	}
+	return wires, nil
+}
+
+// ExportJSON writes the constraints of the constraint system over the curve in JSON
+func ExportJSON(w io.Writer, ccs constraint.ConstraintSystem, curveID ecc.ID) error {
+	if NbCommitments(ccs) > 0 {
+		return errors.New("the commitments of the circuit are bound to the gnark prover and cannot be exported")
+	}
+	isR1CS, err := IsR1CS(ccs)
+	if err != nil {
+		return err
+	}
+	internal, secret, public := ccs.GetNbVariables()
+	export := ExportedSystem{
+		Curve:    strings.ToLower(curveID.String()),
+		Field:    ccs.Field().String(),
+		System:   "plonk",
+		NbWires:  internal + secret + public,
+		NbPublic: public,
+		NbSecret: secret,
+	}
+	coeff := func(cID uint32) string {
+		return ccs.ToBigInt(ccs.GetCoefficient(int(cID))).String()
+	}
+	terms := func(l constraint.LinearExpression) []ExportedTerm {
+		res := make([]ExportedTerm, len(l))
+		for i, t := range l {
+			res[i] = ExportedTerm{Wire: int(t.VID), Coeff: coeff(t.CID)}
+		}
+		return res
+	}
+
+	if isR1CS {
+		export.System = "r1cs"
+		for _, c := range ccs.(constraint.R1CS).GetR1Cs() {
+			export.Constraints = append(export.Constraints, ExportedR1C{A: terms(c.L), B: terms(c.R), C: terms(c.O)})
+		}
+	} else {
+		// -x + PI == 0 for every public wire x, PI being set by the verifier
+		minusOne := ccs.ToBigInt(ccs.Neg(ccs.One())).String()
+		for i := 0; i < public; i++ {
+			export.Gates = append(export.Gates, ExportedGate{A: i, QL: minusOne, QR: "0", QO: "0", QM: "0", QC: "0"})
+		}
+		for _, c := range ccs.(constraint.SparseR1CS).GetSparseR1Cs() {
+			export.Gates = append(export.Gates, ExportedGate{
+				A: int(c.XA), B: int(c.XB), C: int(c.XC),
+				QL: coeff(c.QL), QR: coeff(c.QR), QO: coeff(c.QO), QM: coeff(c.QM), QC: coeff(c.QC),
+			})
+		}
+	}
+
+	return json.NewEncoder(w).Encode(export)
+}
+
+// ExportWitnessJSON writes the value of every wire over the curve in JSON
+func ExportWitnessJSON(w io.Writer, wires []*big.Int, curveID ecc.ID) error {
+	export := ExportedWitness{
+		Curve: strings.ToLower(curveID.String()),
+		Field: curveID.ScalarField().String(),
+		Wires: make([]string, len(wires)),
+	}
+	for i, v := range wires {
+		export.Wires[i] = v.String()
+	}
+	return json.NewEncoder(w).Encode(export)
+}
+
+// bigInts converts a vector of field elements of gnark-crypto, e.g. an fr.Vector, to integers
+func bigInts(v reflect.Value) []*big.Int {
+	res := make([]*big.Int, v.Len())
+	for i := range res {
+		e := v.Index(i).Addr().Interface().(interface{ BigInt(*big.Int) *big.Int })
+		res[i] = e.BigInt(new(big.Int))
+	}
+	return res
+}
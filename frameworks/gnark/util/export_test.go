@@ -0,0 +1,119 @@
+package util
+
+import (
+	"bytes"
+	"encoding/json"
+	"math/big"
+	"testing"
+
+	"github.com/consensys/gnark-crypto/ecc"
+	"github.com/consensys/gnark/frontend"
+	"github.com/consensys/gnark/frontend/cs/r1cs"
+	"github.com/consensys/gnark/frontend/cs/scs"
+	"github.com/consensys/gnark/test"
+	"github.com/zkCollective/zk-Harness/frameworks/gnark/circuits/toy/cubic"
+	"github.com/zkCollective/zk-Harness/frameworks/gnark/circuits/toy/rangecheck"
+)
+
+// unsatisfied returns the indices of the constraints or gates of the export not satisfied by the wires
+func unsatisfied(export ExportedSystem, wires []*big.Int) []int {
+	field, _ := new(big.Int).SetString(export.Field, 10)
+	value := func(s string) *big.Int {
+		v, _ := new(big.Int).SetString(s, 10)
+		return v
+	}
+	eval := func(terms []ExportedTerm) *big.Int {
+		res := new(big.Int)
+		for _, t := range terms {
+			res.Add(res, new(big.Int).Mul(value(t.Coeff), wires[t.Wire]))
+		}
+		return res
+	}
+
+	var res []int
+	for i, c := range export.Constraints {
+		lhs := new(big.Int).Mul(eval(c.A), eval(c.B))
+		if lhs.Sub(lhs, eval(c.C)).Mod(lhs, field).Sign() != 0 {
+			res = append(res, i)
+		}
+	}
+	for i, g := range export.Gates {
+		sum := new(big.Int).Mul(value(g.QL), wires[g.A])
+		sum.Add(sum, new(big.Int).Mul(value(g.QR), wires[g.B]))
+		sum.Add(sum, new(big.Int).Mul(value(g.QO), wires[g.C]))
+		sum.Add(sum, new(big.Int).Mul(value(g.QM), new(big.Int).Mul(wires[g.A], wires[g.B])))
+		sum.Add(sum, value(g.QC))
+		// the gates of the public wires are completed by the public inputs, here the wires themselves
+		if i < export.NbPublic {
+			sum.Add(sum, wires[i])
+		}
+		if sum.Mod(sum, field).Sign() != 0 {
+			res = append(res, i)
+		}
+	}
+	return res
+}
+
+func TestExportJSON(t *testing.T) {
+	assert := test.NewAssert(t)
+
+	for _, tc := range []struct {
+		name       string
+		circuit    frontend.Circuit
+		assignment frontend.Circuit
+	}{
+		{"cubic", &cubic.CubicCircuit{}, &cubic.CubicCircuit{X: 3, Y: 35}},
+		{"rangecheck", &rangecheck.RangeCheckCircuit{X: make([]frontend.Variable, 4), Bits: 8}, rangecheck.Assignment(4, 8)},
+	} {
+		for _, curveID := range []ecc.ID{ecc.BN254, ecc.BLS12_381} {
+			for _, builder := range []frontend.NewBuilder{r1cs.NewBuilder, scs.NewBuilder} {
+				ccs, err := frontend.Compile(curveID.ScalarField(), builder, tc.circuit)
+				assert.NoError(err, tc.name)
+				w, err := frontend.NewWitness(tc.assignment, curveID.ScalarField())
+				assert.NoError(err, tc.name)
+				wires, err := SolvedWires(ccs, w)
+				assert.NoError(err, tc.name)
+
+				var bSystem, bWitness bytes.Buffer
+				assert.NoError(ExportJSON(&bSystem, ccs, curveID))
+				assert.NoError(ExportWitnessJSON(&bWitness, wires, curveID))
+				var export ExportedSystem
+				assert.NoError(json.Unmarshal(bSystem.Bytes(), &export))
+				var exportedWitness ExportedWitness
+				assert.NoError(json.Unmarshal(bWitness.Bytes(), &exportedWitness))
+
+				isR1CS, err := IsR1CS(ccs)
+				assert.NoError(err)
+				internal, secret, public := ccs.GetNbVariables()
+				assert.Equal(internal+secret+public, export.NbWires, tc.name)
+				assert.Equal(export.NbWires, len(exportedWitness.Wires), tc.name)
+				assert.Equal(export.Field, exportedWitness.Field)
+				if isR1CS {
+					assert.Equal("r1cs", export.System)
+					assert.Equal(ccs.GetNbConstraints(), len(export.Constraints), tc.name)
+					assert.Empty(export.Gates)
+				} else {
+					assert.Equal("plonk", export.System)
+					assert.Equal(public+ccs.GetNbConstraints(), len(export.Gates), tc.name)
+					assert.Empty(export.Constraints)
+				}
+
+				// the exported constraints hold on the exported wires, and not on other wires
+				exported := make([]*big.Int, len(exportedWitness.Wires))
+				for i, v := range exportedWitness.Wires {
+					exported[i], _ = new(big.Int).SetString(v, 10)
+				}
+				assert.Empty(unsatisfied(export, exported), "%s over %s, r1cs %t", tc.name, curveID, isR1CS)
+				for i := range exported {
+					exported[i].Add(exported[i], big.NewInt(1))
+					assert.NotEmpty(unsatisfied(export, exported), "%s over %s, r1cs %t: wire %d changed", tc.name, curveID, isR1CS, i)
+					exported[i].Sub(exported[i], big.NewInt(1))
+				}
+			}
+		}
+	}
+
+	ccs, err := frontend.Compile(ecc.BN254.ScalarField(), r1cs.NewBuilder, &rangecheck.RangeCheckCircuit{X: make([]frontend.Variable, 4), Bits: 8, Commit: true})
+	assert.NoError(err)
+	assert.Error(ExportJSON(&bytes.Buffer{}, ccs, ecc.BN254))
+}
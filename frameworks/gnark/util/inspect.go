@@ -0,0 +1,142 @@
+package util
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/consensys/gnark/constraint"
+	cs_bls12377 "github.com/consensys/gnark/constraint/bls12-377"
+	cs_bls12381 "github.com/consensys/gnark/constraint/bls12-381"
+	cs_bls24315 "github.com/consensys/gnark/constraint/bls24-315"
+	cs_bls24317 "github.com/consensys/gnark/constraint/bls24-317"
+	cs_bn254 "github.com/consensys/gnark/constraint/bn254"
+	cs_bw6633 "github.com/consensys/gnark/constraint/bw6-633"
+	cs_bw6761 "github.com/consensys/gnark/constraint/bw6-761"
+	"github.com/consensys/gnark/constraint/solver"
+	cs_tinyfield "github.com/consensys/gnark/constraint/tinyfield"
+)
+
+// HintStat records how often a hint is called when solving a constraint system
+type HintStat struct {
+	Name  string
+	Calls int
+}
+
+// CircuitStats holds the structural statistics of a compiled constraint system
+type CircuitStats struct {
+	NbConstraints       int
+	NbInternalVariables int
+	NbSecretVariables   int
+	NbPublicVariables   int
+	NbCoefficients      int
+	NbCommitments       int
+	NbHintCalls         int
+	Hints               []HintStat
+	NbLevels            int
+	MaxLevelWidth       int
+	AvgLevelWidth       float64
+}
+
+// HintCalls returns the call counts of the hints as name=calls pairs separated by ";", most called first
+func (stats CircuitStats) HintCalls() string {
+	calls := make([]string, len(stats.Hints))
+	for i, h := range stats.Hints {
+		calls[i] = h.Name + "=" + strconv.Itoa(h.Calls)
+	}
+	return strings.Join(calls, ";")
+}
+
+// NbCommitments returns the number of in-circuit commitments of the constraint system, from api.Commit
+// or the lookups and range checks committing to their queries
+func NbCommitments(ccs constraint.ConstraintSystem) int {
+	if commitments := ccs.GetCommitments(); commitments != nil {
+		return len(commitments.CommitmentIndexes())
+	}
+	return 0
+}
+
+// InspectConstraintSystem collects the statistics of a compiled constraint system,
+// including the hint calls and the level structure used by the parallel solver
+func InspectConstraintSystem(ccs constraint.ConstraintSystem) (CircuitStats, error) {
+	internal, secret, public := ccs.GetNbVariables()
+	stats := CircuitStats{
+		NbConstraints:       ccs.GetNbConstraints(),
+		NbInternalVariables: internal,
+		NbSecretVariables:   secret,
+		NbPublicVariables:   public,
+		NbCoefficients:      ccs.GetNbCoefficients(),
+		NbCommitments:       NbCommitments(ccs),
+	}
+
+	system, err := coreSystem(ccs)
+	if err != nil {
+		return stats, err
+	}
+
+	// count the hint calls, resolving the hint names registered at compile time
+	calls := make(map[solver.HintID]int)
+	var mapping constraint.HintMapping
+	for _, inst := range system.Instructions {
+		blueprint, ok := system.Blueprints[inst.BlueprintID].(constraint.BlueprintHint)
+		if !ok {
+			continue
+		}
+		blueprint.DecompressHint(&mapping, inst.Unpack(system))
+		calls[mapping.HintID]++
+		stats.NbHintCalls++
+	}
+	for id, n := range calls {
+		name, ok := system.MHintsDependencies[id]
+		if !ok {
+			name = "unknown"
+		}
+		stats.Hints = append(stats.Hints, HintStat{Name: name, Calls: n})
+	}
+	sort.Slice(stats.Hints, func(i, j int) bool {
+		if stats.Hints[i].Calls != stats.Hints[j].Calls {
+			return stats.Hints[i].Calls > stats.Hints[j].Calls
+		}
+		return stats.Hints[i].Name < stats.Hints[j].Name
+	})
+
+	// each level contains independent instructions the solver runs in parallel
+	stats.NbLevels = len(system.Levels)
+	total := 0
+	for _, level := range system.Levels {
+		total += len(level)
+		if len(level) > stats.MaxLevelWidth {
+			stats.MaxLevelWidth = len(level)
+		}
+	}
+	if stats.NbLevels > 0 {
+		stats.AvgLevelWidth = float64(total) / float64(stats.NbLevels)
+	}
+
+	return stats, nil
+}
+
+// coreSystem returns the curve agnostic part embedded in every curve specific constraint system
+func coreSystem(ccs constraint.ConstraintSystem) (*constraint.System, error) {
+	switch c := ccs.(type) {
+	case *cs_bn254.R1CS:
+		return &c.System, nil
+	case *cs_bls12377.R1CS:
+		return &c.System, nil
+	case *cs_bls12381.R1CS:
+		return &c.System, nil
+	case *cs_bls24315.R1CS:
+		return &c.System, nil
+	case *cs_bls24317.R1CS:
+		return &c.System, nil
+	case *cs_bw6633.R1CS:
+		return &c.System, nil
+	case *cs_bw6761.R1CS:
+		return &c.System, nil
+	case *cs_tinyfield.R1CS:
+		return &c.System, nil
+	default:
+		return nil, fmt.Errorf("unexpected constraint system type %T", ccs)
+	}
+}
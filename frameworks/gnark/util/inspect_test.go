@@ -0,0 +1,53 @@
+package util
+
+import (
+	"testing"
+
+	"github.com/consensys/gnark-crypto/ecc"
+	"github.com/consensys/gnark/frontend"
+	"github.com/consensys/gnark/frontend/cs/r1cs"
+	"github.com/consensys/gnark/frontend/cs/scs"
+	"github.com/consensys/gnark/test"
+	"github.com/zkCollective/zk-Harness/frameworks/gnark/circuits/toy/rangecheck"
+)
+
+func TestInspectConstraintSystem(t *testing.T) {
+	assert := test.NewAssert(t)
+
+	const n, bits = 4, 8
+	compile := func(builder frontend.NewBuilder, commit bool) CircuitStats {
+		circuit := &rangecheck.RangeCheckCircuit{X: make([]frontend.Variable, n), Bits: bits, Commit: commit}
+		ccs, err := frontend.Compile(ecc.BN254.ScalarField(), builder, circuit)
+		assert.NoError(err)
+		stats, err := InspectConstraintSystem(ccs)
+		assert.NoError(err)
+		return stats
+	}
+
+	// every value is decomposed by one bits.nBits hint call into 8 boolean bits recomposed by one
+	// constraint, and the sum takes the last constraint
+	stats := compile(r1cs.NewBuilder, false)
+	assert.Equal(n*bits+n+1, stats.NbConstraints)
+	assert.Equal(n, stats.NbSecretVariables)
+	assert.Equal(2, stats.NbPublicVariables, "the constant one wire and Sum")
+	assert.Equal(0, stats.NbCommitments)
+	assert.Equal(n, stats.NbHintCalls)
+	assert.Equal([]HintStat{{Name: "github.com/consensys/gnark/std/math/bits.nBits", Calls: n}}, stats.Hints)
+	assert.Equal("github.com/consensys/gnark/std/math/bits.nBits=4", stats.HintCalls())
+	assert.True(stats.NbLevels > 0 && stats.MaxLevelWidth > 0)
+
+	stats = compile(scs.NewBuilder, false)
+	assert.Equal(1, stats.NbPublicVariables, "Sum, PlonK having no constant wire")
+	assert.Equal(n, stats.NbHintCalls)
+
+	// the log-derivative range check commits to the values and calls its own hints
+	for _, builder := range []frontend.NewBuilder{r1cs.NewBuilder, scs.NewBuilder} {
+		stats = compile(builder, true)
+		assert.Equal(1, stats.NbCommitments)
+		total := 0
+		for _, h := range stats.Hints {
+			total += h.Calls
+		}
+		assert.Equal(stats.NbHintCalls, total, "per-hint calls add up")
+	}
+}
@@ -0,0 +1,110 @@
+package util
+
+import (
+	"bufio"
+	"os"
+	"runtime"
+	"runtime/debug"
+	"strings"
+)
+
+// Machine describes the CPU the benchmarks run on
+type Machine struct {
+	NbPhysicalCores int
+	NbLogicalCores  int
+	CPU             string
+}
+
+// DescribeMachine reads the CPU model and the number of physical cores from /proc/cpuinfo,
+// falling back to the logical cores and the architecture where it is not available
+func DescribeMachine() Machine {
+	m := Machine{
+		NbLogicalCores: runtime.NumCPU(),
+		CPU:            runtime.GOARCH,
+	}
+
+	f, err := os.Open("/proc/cpuinfo")
+	if err == nil {
+		defer f.Close()
+		cores := make(map[string]struct{})
+		var physicalID string
+		scanner := bufio.NewScanner(f)
+		for scanner.Scan() {
+			key, value, ok := strings.Cut(scanner.Text(), ":")
+			if !ok {
+				continue
+			}
+			key, value = strings.TrimSpace(key), strings.TrimSpace(value)
+			switch key {
+			case "model name":
+				m.CPU = value
+			case "physical id":
+				physicalID = value
+			case "core id":
+				cores[physicalID+"/"+value] = struct{}{}
+			}
+		}
+		m.NbPhysicalCores = len(cores)
+	}
+	if m.NbPhysicalCores == 0 {
+		m.NbPhysicalCores = m.NbLogicalCores
+	}
+	return m
+}
+
+// Host describes the machine the harness runs on and the versions it is built with, tagging its results
+type Host struct {
+	Name            string `json:"name"`
+	OS              string `json:"os"`
+	Arch            string `json:"arch"`
+	CPU             string `json:"cpu"`
+	NbPhysicalCores int    `json:"nbPhysicalCores"`
+	NbLogicalCores  int    `json:"nbLogicalCores"`
+	GoVersion       string `json:"goVersion"`
+	Gnark           string `json:"gnark"`
+	GnarkCrypto     string `json:"gnarkCrypto"`
+	// git commit of the harness, suffixed with +dirty for uncommitted changes
+	Revision string `json:"revision"`
+}
+
+// DescribeHost describes the machine and reads the versions of gnark and of the harness from the build
+func DescribeHost() Host {
+	m := DescribeMachine()
+	h := Host{
+		OS:              runtime.GOOS,
+		Arch:            runtime.GOARCH,
+		CPU:             m.CPU,
+		NbPhysicalCores: m.NbPhysicalCores,
+		NbLogicalCores:  m.NbLogicalCores,
+		GoVersion:       runtime.Version(),
+		Gnark:           "unknown",
+		GnarkCrypto:     "unknown",
+		Revision:        "unknown",
+	}
+	h.Name, _ = os.Hostname()
+	info, ok := debug.ReadBuildInfo()
+	if !ok {
+		return h
+	}
+	for _, dep := range info.Deps {
+		switch dep.Path {
+		case "github.com/consensys/gnark":
+			h.Gnark = dep.Version
+		case "github.com/consensys/gnark-crypto":
+			h.GnarkCrypto = dep.Version
+		}
+	}
+	var modified bool
+	for _, setting := range info.Settings {
+		switch setting.Key {
+		case "vcs.revision":
+			h.Revision = setting.Value
+		case "vcs.modified":
+			modified = setting.Value == "true"
+		}
+	}
+	if modified {
+		h.Revision += "+dirty"
+	}
+	return h
+}
@@ -0,0 +1,135 @@
+package util
+
+import (
+	"crypto/sha256"
+	"errors"
+	"hash"
+	"strconv"
+	"strings"
+
+	"github.com/consensys/gnark-crypto/ecc"
+	"github.com/consensys/gnark/backend"
+	"github.com/consensys/gnark/constraint/solver"
+	"github.com/consensys/gnark/std/recursion"
+	"golang.org/x/crypto/sha3"
+)
+
+// BackendOptions are the prover and verifier options of a backend, the hash functions being named
+// as in NewHash, default keeping the one of gnark
+type BackendOptions struct {
+	// HashToField hashes the commitments to the field
+	HashToField string
+	// ChallengeHash derives the Fiat-Shamir challenges of PlonK
+	ChallengeHash string
+	// KZGFoldingHash derives the challenge folding the KZG openings of PlonK
+	KZGFoldingHash string
+	// SolverTasks is the number of tasks the solver splits its levels into, 0 for the default.
+	// It is the only solver option, the hints are the registered ones of gnark
+	SolverTasks int
+}
+
+// NewHash returns the hash function of the given name over the curve: sha256, keccak256 or mimc,
+// the latter over the scalar field of the curve reading its input as bits, as the recursive verifiers
+func NewHash(name string, curveID ecc.ID) (hash.Hash, error) {
+	switch name {
+	case "sha256":
+		return sha256.New(), nil
+	case "keccak256":
+		return sha3.NewLegacyKeccak256(), nil
+	case "mimc":
+		return recursion.NewShort(curveID.ScalarField(), curveID.ScalarField())
+	default:
+		return nil, errors.New("invalid hash function " + name + ", must be default, sha256, keccak256 or mimc")
+	}
+}
+
+// Validate checks the hash functions exist over the curve and the number of solver tasks
+func (o BackendOptions) Validate(curveID ecc.ID) error {
+	for _, name := range []string{o.HashToField, o.ChallengeHash, o.KZGFoldingHash} {
+		if !customHash(name) {
+			continue
+		}
+		if _, err := NewHash(name, curveID); err != nil {
+			return err
+		}
+	}
+	if o.SolverTasks < 0 {
+		return errors.New("solver tasks must be >= 0")
+	}
+	return nil
+}
+
+// IsDefault is true if all options are those of gnark
+func (o BackendOptions) IsDefault() bool {
+	return o.String() == "default"
+}
+
+// String lists the options differing from the defaults, e.g. challengeHash=mimc;solverTasks=4, or default
+func (o BackendOptions) String() string {
+	var options []string
+	for _, option := range []struct{ key, name string }{
+		{"hashToField", o.HashToField},
+		{"challengeHash", o.ChallengeHash},
+		{"kzgFoldingHash", o.KZGFoldingHash},
+	} {
+		if customHash(option.name) {
+			options = append(options, option.key+"="+option.name)
+		}
+	}
+	if o.SolverTasks > 0 {
+		options = append(options, "solverTasks="+strconv.Itoa(o.SolverTasks))
+	}
+	if len(options) == 0 {
+		return "default"
+	}
+	return strings.Join(options, ";")
+}
+
+// ProverOptions returns the options of the prover, each call of the prover getting new hash functions
+func (o BackendOptions) ProverOptions(curveID ecc.ID) []backend.ProverOption {
+	var opts []backend.ProverOption
+	if customHash(o.HashToField) {
+		opts = append(opts, withHash(o.HashToField, curveID, backend.WithProverHashToFieldFunction))
+	}
+	if customHash(o.ChallengeHash) {
+		opts = append(opts, withHash(o.ChallengeHash, curveID, backend.WithProverChallengeHashFunction))
+	}
+	if customHash(o.KZGFoldingHash) {
+		opts = append(opts, withHash(o.KZGFoldingHash, curveID, backend.WithProverKZGFoldingHashFunction))
+	}
+	if o.SolverTasks > 0 {
+		opts = append(opts, backend.WithSolverOptions(solver.WithNbTasks(o.SolverTasks)))
+	}
+	return opts
+}
+
+// VerifierOptions returns the options of the verifier matching those of the prover
+func (o BackendOptions) VerifierOptions(curveID ecc.ID) []backend.VerifierOption {
+	var opts []backend.VerifierOption
+	if customHash(o.HashToField) {
+		opts = append(opts, withHash(o.HashToField, curveID, backend.WithVerifierHashToFieldFunction))
+	}
+	if customHash(o.ChallengeHash) {
+		opts = append(opts, withHash(o.ChallengeHash, curveID, backend.WithVerifierChallengeHashFunction))
+	}
+	if customHash(o.KZGFoldingHash) {
+		opts = append(opts, withHash(o.KZGFoldingHash, curveID, backend.WithVerifierKZGFoldingHashFunction))
+	}
+	return opts
+}
+
+// customHash is true if the hash function is set and not the default of gnark
+func customHash(name string) bool {
+	return name != "default" && name != ""
+}
+
+// withHash returns the option setting a new hash function of the given name when applied
+func withHash[C any, O ~func(*C) error](name string, curveID ecc.ID, option func(hash.Hash) O) O {
+	return O(func(config *C) error {
+		h, err := NewHash(name, curveID)
+		if err != nil {
+			return err
+		}
+		return option(h)(config)
+	})
+}
@@ -21,10 +21,14 @@ type BenchDataCurve struct {
 	MaxRAM    uint64
 	Count     int
 	RunTime   int64
+	Machine
+	// Throughput in elements per second and fitted cost in ns per n*log2(n), for sized operations
+	Throughput float64
+	NLogNCost  float64
 }
 
 func (bDataCurve BenchDataCurve) Headers() []string {
-	return []string{"framework", "category", "curve", "operation", "input", "ram", "time", "nbPhysicalCores", "nbLogicalCores", "count", "cpu"}
+	return []string{"framework", "category", "curve", "operation", "input", "ram", "time", "nbPhysicalCores", "nbLogicalCores", "count", "cpu", "throughput", "nlognCost"}
 }
 
 func (bDataCurve BenchDataCurve) Values() []string {
@@ -35,8 +39,13 @@ func (bDataCurve BenchDataCurve) Values() []string {
 		bDataCurve.Operation,
 		bDataCurve.Input,
 		strconv.Itoa(int(bDataCurve.MaxRAM)),
-		strconv.Itoa(int(bDataCurve.Count)),
 		strconv.Itoa(int(bDataCurve.RunTime)),
+		strconv.Itoa(bDataCurve.NbPhysicalCores),
+		strconv.Itoa(bDataCurve.NbLogicalCores),
+		strconv.Itoa(int(bDataCurve.Count)),
+		bDataCurve.CPU,
+		strconv.FormatFloat(bDataCurve.Throughput, 'f', 2, 64),
+		strconv.FormatFloat(bDataCurve.NLogNCost, 'f', 4, 64),
 	}
 }
 
@@ -51,14 +60,18 @@ type BenchDataCircuit struct {
 	NbConstraints     int
 	NbSecretVariables int
 	NbPublicVariables int
-	MaxRAM            uint64
-	Count             int
-	RunTime           int64
-	ProofSize         int
+	// number of BSB22 commitments, each adding a Pedersen commitment to the Groth16 proof
+	NbCommitments int
+	MaxRAM        uint64
+	Count         int
+	RunTime       int64
+	ProofSize     int
+	// prover and verifier options differing from the defaults of gnark
+	Options string
 }
 
 func (bDataCirc BenchDataCircuit) Headers() []string {
-	return []string{"framework", "category", "backend", "curve", "circuit", "input", "operation", "nbConstraints", "nbSecret", "nbPublic", "ram", "time", "proofSize", "count"}
+	return []string{"framework", "category", "backend", "curve", "circuit", "input", "operation", "nbConstraints", "nbSecret", "nbPublic", "nbCommitments", "ram", "time", "proofSize", "count", "options"}
 }
 
 func (bDataCirc BenchDataCircuit) Values() []string {
@@ -73,10 +86,12 @@ func (bDataCirc BenchDataCircuit) Values() []string {
 		strconv.Itoa(int(bDataCirc.NbConstraints)),
 		strconv.Itoa(int(bDataCirc.NbSecretVariables)),
 		strconv.Itoa(int(bDataCirc.NbPublicVariables)),
+		strconv.Itoa(bDataCirc.NbCommitments),
 		strconv.Itoa(int(bDataCirc.MaxRAM)),
 		strconv.Itoa(int(bDataCirc.RunTime)),
 		strconv.Itoa(int(bDataCirc.ProofSize)),
 		strconv.Itoa(int(bDataCirc.Count)),
+		bDataCirc.Options,
 	}
 }
 
@@ -98,10 +113,26 @@ type BenchDataRecursion struct {
 	RunTime            int64
 	ProofSize          int
 	Count              int
+
+	// number of aggregated inner proofs, 1 for recursion
+	NbProofs            int
+	Aggregation         string
+	ConstraintsPerProof float64
+
+	// non-native overhead of the emulated recursion, relative to the verifier over the 2-chain of
+	// NativeInnerCurve and NativeOuterCurve, the curves of the record if the recursion is native
+	Emulated            bool
+	NativeInnerCurve    string
+	NativeOuterCurve    string
+	NativeNbConstraints int
+	Overhead            float64
+
+	// level of the recursion chain, 1 for the verifier of the inner circuit
+	Level int
 }
 
 func (bDataCirc BenchDataRecursion) Headers() []string {
-	return []string{"framework", "category", "innerBackend", "outerBackend", "innerCurve", "outerCurve", "circuit", "input", "operation", "innerNbConstraints", "outerNbConstraints", "nbSecret", "nbPublic", "ram", "time", "proofSize", "count"}
+	return []string{"framework", "category", "innerBackend", "outerBackend", "innerCurve", "outerCurve", "circuit", "input", "operation", "innerNbConstraints", "outerNbConstraints", "nbSecret", "nbPublic", "ram", "time", "proofSize", "count", "emulated", "nativeInnerCurve", "nativeOuterCurve", "nativeNbConstraints", "overhead", "nbProofs", "aggregation", "constraintsPerProof", "level"}
 }
 
 func (bDataCirc BenchDataRecursion) Values() []string {
@@ -123,5 +154,241 @@ func (bDataCirc BenchDataRecursion) Values() []string {
 		strconv.Itoa(int(bDataCirc.RunTime)),
 		strconv.Itoa(int(bDataCirc.ProofSize)),
 		strconv.Itoa(int(bDataCirc.Count)),
+		strconv.FormatBool(bDataCirc.Emulated),
+		bDataCirc.NativeInnerCurve,
+		bDataCirc.NativeOuterCurve,
+		strconv.Itoa(bDataCirc.NativeNbConstraints),
+		strconv.FormatFloat(bDataCirc.Overhead, 'f', 2, 64),
+		strconv.Itoa(bDataCirc.NbProofs),
+		bDataCirc.Aggregation,
+		strconv.FormatFloat(bDataCirc.ConstraintsPerProof, 'f', 2, 64),
+		strconv.Itoa(bDataCirc.Level),
+	}
+}
+
+type BenchDataStats struct {
+	Framework           string
+	Category            string
+	Backend             string
+	Curve               string
+	Circuit             string
+	Input               string
+	NbConstraints       int
+	NbInternalVariables int
+	NbSecretVariables   int
+	NbPublicVariables   int
+	NbCoefficients      int
+	NbCommitments       int
+	NbHintCalls         int
+	Hints               string
+	NbLevels            int
+	MaxLevelWidth       int
+	AvgLevelWidth       float64
+}
+
+func (bDataStats BenchDataStats) Headers() []string {
+	return []string{"framework", "category", "backend", "curve", "circuit", "input", "nbConstraints", "nbInternal", "nbSecret", "nbPublic", "nbCoefficients", "nbCommitments", "nbHintCalls", "hints", "nbLevels", "maxLevelWidth", "avgLevelWidth"}
+}
+
+func (bDataStats BenchDataStats) Values() []string {
+	return []string{
+		bDataStats.Framework,
+		bDataStats.Category,
+		bDataStats.Backend,
+		bDataStats.Curve,
+		bDataStats.Circuit,
+		bDataStats.Input,
+		strconv.Itoa(int(bDataStats.NbConstraints)),
+		strconv.Itoa(int(bDataStats.NbInternalVariables)),
+		strconv.Itoa(int(bDataStats.NbSecretVariables)),
+		strconv.Itoa(int(bDataStats.NbPublicVariables)),
+		strconv.Itoa(int(bDataStats.NbCoefficients)),
+		strconv.Itoa(int(bDataStats.NbCommitments)),
+		strconv.Itoa(int(bDataStats.NbHintCalls)),
+		bDataStats.Hints,
+		strconv.Itoa(int(bDataStats.NbLevels)),
+		strconv.Itoa(int(bDataStats.MaxLevelWidth)),
+		strconv.FormatFloat(bDataStats.AvgLevelWidth, 'f', 2, 64),
+	}
+}
+
+type BenchDataScaling struct {
+	Framework     string
+	Category      string
+	Backend       string
+	Curve         string
+	Circuit       string
+	Input         string
+	Operation     string
+	NbConstraints int
+	Threads       int
+	RunTime       int64
+	Speedup       float64
+	Efficiency    float64
+	Count         int
+}
+
+func (bDataScaling BenchDataScaling) Headers() []string {
+	return []string{"framework", "category", "backend", "curve", "circuit", "input", "operation", "nbConstraints", "threads", "time", "speedup", "efficiency", "count"}
+}
+
+func (bDataScaling BenchDataScaling) Values() []string {
+	return []string{
+		bDataScaling.Framework,
+		bDataScaling.Category,
+		bDataScaling.Backend,
+		bDataScaling.Curve,
+		bDataScaling.Circuit,
+		bDataScaling.Input,
+		bDataScaling.Operation,
+		strconv.Itoa(int(bDataScaling.NbConstraints)),
+		strconv.Itoa(int(bDataScaling.Threads)),
+		strconv.Itoa(int(bDataScaling.RunTime)),
+		strconv.FormatFloat(bDataScaling.Speedup, 'f', 2, 64),
+		strconv.FormatFloat(bDataScaling.Efficiency, 'f', 2, 64),
+		strconv.Itoa(int(bDataScaling.Count)),
+	}
+}
+
+type BenchDataMemory struct {
+	Framework string
+	Category  string
+	Backend   string
+	Curve     string
+	Circuit   string
+	Input     string
+	Operation string
+	// peak resident set size of the child process running the phase, in kilobytes
+	MaxRSS  uint64
+	RunTime int64
+}
+
+func (bDataMemory BenchDataMemory) Headers() []string {
+	return []string{"framework", "category", "backend", "curve", "circuit", "input", "operation", "maxRSS", "time"}
+}
+
+func (bDataMemory BenchDataMemory) Values() []string {
+	return []string{
+		bDataMemory.Framework,
+		bDataMemory.Category,
+		bDataMemory.Backend,
+		bDataMemory.Curve,
+		bDataMemory.Circuit,
+		bDataMemory.Input,
+		bDataMemory.Operation,
+		strconv.FormatUint(bDataMemory.MaxRSS, 10),
+		strconv.Itoa(int(bDataMemory.RunTime)),
+	}
+}
+
+type BenchDataSerialization struct {
+	Framework string
+	Category  string
+	Backend   string
+	Curve     string
+	Circuit   string
+	Input     string
+	Object    string
+	Encoding  string
+	Operation string
+	Bytes     int
+	Count     int
+	RunTime   int64
+}
+
+func (bDataSerial BenchDataSerialization) Headers() []string {
+	return []string{"framework", "category", "backend", "curve", "circuit", "input", "object", "encoding", "operation", "bytes", "time", "count"}
+}
+
+func (bDataSerial BenchDataSerialization) Values() []string {
+	return []string{
+		bDataSerial.Framework,
+		bDataSerial.Category,
+		bDataSerial.Backend,
+		bDataSerial.Curve,
+		bDataSerial.Circuit,
+		bDataSerial.Input,
+		bDataSerial.Object,
+		bDataSerial.Encoding,
+		bDataSerial.Operation,
+		strconv.Itoa(bDataSerial.Bytes),
+		strconv.Itoa(int(bDataSerial.RunTime)),
+		strconv.Itoa(bDataSerial.Count),
+	}
+}
+
+type BenchDataCeremony struct {
+	Framework     string
+	Category      string
+	Backend       string
+	Curve         string
+	Circuit       string
+	Input         string
+	Operation     string
+	Contributor   int
+	NbConstraints int
+	Power         int
+	// size of the serialized state produced by the operation, in bytes
+	Size    int
+	RunTime int64
+}
+
+func (bDataCeremony BenchDataCeremony) Headers() []string {
+	return []string{"framework", "category", "backend", "curve", "circuit", "input", "operation", "contributor", "nbConstraints", "power", "size", "time"}
+}
+
+func (bDataCeremony BenchDataCeremony) Values() []string {
+	return []string{
+		bDataCeremony.Framework,
+		bDataCeremony.Category,
+		bDataCeremony.Backend,
+		bDataCeremony.Curve,
+		bDataCeremony.Circuit,
+		bDataCeremony.Input,
+		bDataCeremony.Operation,
+		strconv.Itoa(bDataCeremony.Contributor),
+		strconv.Itoa(bDataCeremony.NbConstraints),
+		strconv.Itoa(bDataCeremony.Power),
+		strconv.Itoa(bDataCeremony.Size),
+		strconv.Itoa(int(bDataCeremony.RunTime)),
+	}
+}
+
+type BenchDataSolidity struct {
+	Framework     string
+	Category      string
+	Backend       string
+	Curve         string
+	Circuit       string
+	Input         string
+	NbConstraints int
+	NbPublic      int
+	// size of the creation bytecode, in bytes
+	BytecodeSize int
+	DeployGas    uint64
+	VerifyGas    uint64
+	CalldataGas  uint64
+	ExecutionGas uint64
+}
+
+func (bDataSolidity BenchDataSolidity) Headers() []string {
+	return []string{"framework", "category", "backend", "curve", "circuit", "input", "nbConstraints", "nbPublic", "bytecodeSize", "deployGas", "verifyGas", "calldataGas", "executionGas"}
+}
+
+func (bDataSolidity BenchDataSolidity) Values() []string {
+	return []string{
+		bDataSolidity.Framework,
+		bDataSolidity.Category,
+		bDataSolidity.Backend,
+		bDataSolidity.Curve,
+		bDataSolidity.Circuit,
+		bDataSolidity.Input,
+		strconv.Itoa(bDataSolidity.NbConstraints),
+		strconv.Itoa(bDataSolidity.NbPublic),
+		strconv.Itoa(bDataSolidity.BytecodeSize),
+		strconv.FormatUint(bDataSolidity.DeployGas, 10),
+		strconv.FormatUint(bDataSolidity.VerifyGas, 10),
+		strconv.FormatUint(bDataSolidity.CalldataGas, 10),
+		strconv.FormatUint(bDataSolidity.ExecutionGas, 10),
 	}
 }
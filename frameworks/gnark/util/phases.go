@@ -0,0 +1,176 @@
+package util
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"runtime/pprof"
+	"strings"
+	"time"
+
+	"github.com/consensys/gnark/logger"
+	"github.com/google/pprof/profile"
+	"github.com/rs/zerolog"
+)
+
+// Phase is the time spent in a sub-phase of a prover run
+type Phase struct {
+	Name string
+	Took time.Duration
+	// Estimated is set when the time is a share of the CPU profile rather than a logged duration
+	Estimated bool
+}
+
+// phaseOrder is the order in which sub-phases are reported
+var phaseOrder = []string{"solve", "msm_g1", "msm_g2", "fft", "ifft", "quotient", "kzg_commit", "kzg_open", "commitment", "other"}
+
+type phaseMatcher struct {
+	phase   string
+	pattern string
+}
+
+// phaseMatchers map a function of the prover call stack to a sub-phase.
+// A sample is attributed to the innermost matching function, such that MSMs and FFTs
+// are accounted as kernels even when called from a KZG commitment or the quotient.
+var phaseMatchers = []phaseMatcher{
+	{"msm_g1", ".(*G1Jac).MultiExp"},
+	{"msm_g1", ".(*G1Affine).MultiExp"},
+	{"msm_g1", ".processChunkG1"},
+	{"msm_g2", ".(*G2Jac).MultiExp"},
+	{"msm_g2", ".(*G2Affine).MultiExp"},
+	{"msm_g2", ".processChunkG2"},
+	{"ifft", "fft.(*Domain).FFTInverse"},
+	{"fft", "fft.(*Domain).FFT"},
+	{"kzg_commit", "kzg.Commit"},
+	{"kzg_open", "kzg.Open"},
+	{"kzg_open", "kzg.BatchOpen"},
+	{"kzg_open", "kzg.FoldProof"},
+	{"commitment", "pedersen."},
+	{"quotient", ".computeH"},
+	{"quotient", ".computeNumerator"},
+	{"quotient", ".divideByXMinusOne"},
+	{"quotient", ".evaluateXnMinusOneDomainBigCoset"},
+}
+
+// fallbackMatchers catch the worker goroutines of the FFT, whose stacks do not tell
+// whether they were spawned by a forward or an inverse transform
+var fallbackMatchers = []phaseMatcher{
+	{"fft", "/fr/fft."},
+}
+
+// solverPackage prefixes the functions of the constraint system solver
+const solverPackage = "github.com/consensys/gnark/constraint/"
+
+// ProvePhases runs prove count times while capturing the gnark debug logger and a CPU profile.
+// The witness solving and total prover durations are read from the logger output, the remaining
+// prover time is split across the MSM, FFT, quotient, KZG and commitment sub-phases according
+// to the share of CPU samples spent in each of them.
+func ProvePhases(count int, prove func() error) ([]Phase, error) {
+	var logs, cpu bytes.Buffer
+
+	previous := logger.Logger()
+	logger.Set(zerolog.New(&logs).Level(zerolog.DebugLevel))
+	defer logger.Set(previous)
+
+	if err := pprof.StartCPUProfile(&cpu); err != nil {
+		return nil, err
+	}
+	start := time.Now()
+	for i := 0; i < count; i++ {
+		if err := prove(); err != nil {
+			pprof.StopCPUProfile()
+			return nil, err
+		}
+	}
+	wall := time.Since(start)
+	pprof.StopCPUProfile()
+
+	solve, total, err := parseProverLogs(&logs)
+	if err != nil {
+		return nil, err
+	}
+	// fall back to the wall time if the prover did not log its duration
+	if total == 0 {
+		total = wall
+	}
+	solve /= time.Duration(count)
+	total /= time.Duration(count)
+
+	p, err := profile.Parse(&cpu)
+	if err != nil {
+		return nil, err
+	}
+	samples := make(map[string]int64)
+	var nbSamples int64
+	for _, s := range p.Sample {
+		phase := classifySample(s)
+		samples[phase] += s.Value[0]
+		if phase != "solve" {
+			nbSamples += s.Value[0]
+		}
+	}
+
+	// the solver duration is exact, scale the sampled phases to the rest of the prover time
+	remaining := total - solve
+	if remaining < 0 {
+		remaining = 0
+	}
+	phases := []Phase{{Name: "solve", Took: solve}}
+	for _, name := range phaseOrder[1:] {
+		var took time.Duration
+		if nbSamples > 0 {
+			took = time.Duration(float64(remaining) * float64(samples[name]) / float64(nbSamples))
+		}
+		phases = append(phases, Phase{Name: name, Took: took, Estimated: true})
+	}
+	return phases, nil
+}
+
+// parseProverLogs sums the durations logged by the constraint system solver and the prover
+func parseProverLogs(logs *bytes.Buffer) (solve, total time.Duration, err error) {
+	scanner := bufio.NewScanner(logs)
+	for scanner.Scan() {
+		var entry struct {
+			Message string  `json:"message"`
+			Took    float64 `json:"took"`
+		}
+		if err := json.Unmarshal(scanner.Bytes(), &entry); err != nil {
+			continue
+		}
+		took := time.Duration(entry.Took * float64(zerolog.DurationFieldUnit))
+		switch entry.Message {
+		case "constraint system solver done":
+			solve += took
+		case "prover done":
+			total += took
+		}
+	}
+	return solve, total, scanner.Err()
+}
+
+// classifySample returns the sub-phase of the innermost matching function in the sample stack.
+// Samples taken while solving, including hints computing commitments, belong to the solver.
+func classifySample(s *profile.Sample) string {
+	for _, loc := range s.Location {
+		for _, line := range loc.Line {
+			if line.Function != nil && strings.HasPrefix(line.Function.Name, solverPackage) {
+				return "solve"
+			}
+		}
+	}
+	for _, matchers := range [][]phaseMatcher{phaseMatchers, fallbackMatchers} {
+		for _, loc := range s.Location {
+			for _, line := range loc.Line {
+				if line.Function == nil {
+					continue
+				}
+				for _, m := range matchers {
+					if strings.Contains(line.Function.Name, m.pattern) {
+						return m.phase
+					}
+				}
+			}
+		}
+	}
+	return "other"
+}
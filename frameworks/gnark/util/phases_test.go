@@ -0,0 +1,70 @@
+package util
+
+import (
+	"bytes"
+	"testing"
+	"time"
+
+	"github.com/consensys/gnark/test"
+	"github.com/google/pprof/profile"
+	"github.com/rs/zerolog"
+)
+
+func TestParseProverLogs(t *testing.T) {
+	assert := test.NewAssert(t)
+
+	// the durations are logged as the loggers of the solver and the prover write them
+	var logs bytes.Buffer
+	log := zerolog.New(&logs)
+	for i := 0; i < 2; i++ {
+		log.Debug().Dur("took", 3*time.Millisecond).Msg("constraint system solver done")
+		log.Debug().Str("curve", "bn254").Msg("computing the proof")
+		log.Debug().Dur("took", 10*time.Millisecond).Msg("prover done")
+	}
+	logs.WriteString("not a json line\n")
+
+	solve, total, err := parseProverLogs(&logs)
+	assert.NoError(err)
+	assert.Equal(6*time.Millisecond, solve)
+	assert.Equal(20*time.Millisecond, total)
+
+	solve, total, err = parseProverLogs(new(bytes.Buffer))
+	assert.NoError(err)
+	assert.Equal(time.Duration(0), solve+total, "no prover logs")
+}
+
+func TestClassifySample(t *testing.T) {
+	// stack builds a sample from the innermost function to the outermost
+	stack := func(functions ...string) *profile.Sample {
+		s := &profile.Sample{}
+		for _, f := range functions {
+			s.Location = append(s.Location, &profile.Location{Line: []profile.Line{{Function: &profile.Function{Name: f}}}})
+		}
+		return s
+	}
+
+	for i, tc := range []struct {
+		sample *profile.Sample
+		phase  string
+	}{
+		{stack("github.com/consensys/gnark-crypto/ecc/bn254.(*G1Jac).MultiExp", "github.com/consensys/gnark/backend/groth16/bn254.Prove"), "msm_g1"},
+		{stack("github.com/consensys/gnark-crypto/ecc/bn254.processChunkG2[...]"), "msm_g2"},
+		// the MSM of a KZG commitment is a kernel
+		{stack("github.com/consensys/gnark-crypto/ecc/bn254.(*G1Affine).MultiExp", "github.com/consensys/gnark-crypto/ecc/bn254/kzg.Commit"), "msm_g1"},
+		{stack("github.com/consensys/gnark-crypto/ecc/bn254/kzg.Commit"), "kzg_commit"},
+		{stack("github.com/consensys/gnark-crypto/ecc/bn254/fr/fft.(*Domain).FFTInverse"), "ifft"},
+		{stack("github.com/consensys/gnark-crypto/ecc/bn254/fr/fft.(*Domain).FFT"), "fft"},
+		{stack("github.com/consensys/gnark/backend/plonk/bn254.(*instance).computeNumerator"), "quotient"},
+		{stack("github.com/consensys/gnark-crypto/ecc/bn254/fr/pedersen.(*ProvingKey).Commit"), "commitment"},
+		// the FFT workers only tell their package apart
+		{stack("github.com/consensys/gnark-crypto/ecc/bn254/fr/fft.difFFT"), "fft"},
+		// hints computing commitments while solving belong to the solver
+		{stack("github.com/consensys/gnark-crypto/ecc/bn254/fr/pedersen.(*ProvingKey).Commit", "github.com/consensys/gnark/constraint/bn254.(*solver).run"), "solve"},
+		{stack("runtime.mallocgc"), "other"},
+		{&profile.Sample{Location: []*profile.Location{{}}}, "other"},
+	} {
+		if phase := classifySample(tc.sample); phase != tc.phase {
+			t.Errorf("sample %d: expected %s, got %s", i, tc.phase, phase)
+		}
+	}
+}
@@ -0,0 +1,157 @@
+package util
+
+import (
+	"bufio"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"math/big"
+
+	"github.com/consensys/gnark-crypto/ecc/bn254"
+	"github.com/consensys/gnark-crypto/ecc/bn254/fp"
+	kzg_bn254 "github.com/consensys/gnark-crypto/ecc/bn254/kzg"
+)
+
+// sections of a .ptau file holding the header and the powers of tau in G1 and G2
+const (
+	ptauHeader = 1
+	ptauTauG1  = 2
+	ptauTauG2  = 3
+)
+
+// ReadPtau reads the first size powers of tau in G1 of a snarkjs .ptau file over BN254 into a canonical SRS.
+// The file is a sequence of sections, each with a type and a length; the points are uncompressed,
+// their coordinates being little endian in Montgomery form, as the fp.Element of gnark-crypto.
+func ReadPtau(r io.ReadSeeker, size int) (*kzg_bn254.SRS, error) {
+	var magic [4]byte
+	if _, err := io.ReadFull(r, magic[:]); err != nil {
+		return nil, err
+	}
+	if string(magic[:]) != "ptau" {
+		return nil, errors.New("not a ptau file")
+	}
+	var version, nbSections uint32
+	if err := binary.Read(r, binary.LittleEndian, &version); err != nil {
+		return nil, err
+	}
+	if err := binary.Read(r, binary.LittleEndian, &nbSections); err != nil {
+		return nil, err
+	}
+
+	// offsets of the sections
+	sections := make(map[uint32]int64)
+	for i := uint32(0); i < nbSections; i++ {
+		var section struct {
+			Type uint32
+			Size uint64
+		}
+		if err := binary.Read(r, binary.LittleEndian, &section); err != nil {
+			return nil, err
+		}
+		offset, err := r.Seek(0, io.SeekCurrent)
+		if err != nil {
+			return nil, err
+		}
+		sections[section.Type] = offset
+		if _, err := r.Seek(int64(section.Size), io.SeekCurrent); err != nil {
+			return nil, err
+		}
+	}
+	for _, s := range []uint32{ptauHeader, ptauTauG1, ptauTauG2} {
+		if _, ok := sections[s]; !ok {
+			return nil, fmt.Errorf("ptau file misses section %d", s)
+		}
+	}
+
+	// the header holds the size of a field element, the modulus and the power of the ceremony
+	br, err := ptauSection(r, sections[ptauHeader])
+	if err != nil {
+		return nil, err
+	}
+	var n8 uint32
+	if err := binary.Read(br, binary.LittleEndian, &n8); err != nil {
+		return nil, err
+	}
+	if n8 != fp.Bytes {
+		return nil, fmt.Errorf("ptau field elements have %d bytes, BN254 has %d", n8, fp.Bytes)
+	}
+	q := make([]byte, n8)
+	if _, err := io.ReadFull(br, q); err != nil {
+		return nil, err
+	}
+	for i, j := 0, len(q)-1; i < j; i, j = i+1, j-1 {
+		q[i], q[j] = q[j], q[i]
+	}
+	if new(big.Int).SetBytes(q).Cmp(fp.Modulus()) != 0 {
+		return nil, errors.New("ptau file is not over BN254")
+	}
+	var power uint32
+	if err := binary.Read(br, binary.LittleEndian, &power); err != nil {
+		return nil, err
+	}
+	// a ceremony of power p has 2^(p+1)-1 powers of tau in G1
+	if nbG1 := (1 << (power + 1)) - 1; size > nbG1 {
+		return nil, fmt.Errorf("ptau file of power %d is too small: got %d points, need %d", power, nbG1, size)
+	}
+
+	var srs kzg_bn254.SRS
+	srs.Pk.G1 = make([]bn254.G1Affine, size)
+	if br, err = ptauSection(r, sections[ptauTauG1]); err != nil {
+		return nil, err
+	}
+	buf := make([]byte, 4*fp.Bytes)
+	for i := range srs.Pk.G1 {
+		if _, err := io.ReadFull(br, buf[:2*fp.Bytes]); err != nil {
+			return nil, err
+		}
+		p := &srs.Pk.G1[i]
+		ptauElement(&p.X, buf[:fp.Bytes])
+		ptauElement(&p.Y, buf[fp.Bytes:])
+		if !p.IsOnCurve() {
+			return nil, fmt.Errorf("ptau point %d in G1 is not on the curve", i)
+		}
+	}
+
+	if br, err = ptauSection(r, sections[ptauTauG2]); err != nil {
+		return nil, err
+	}
+	for i := range srs.Vk.G2 {
+		if _, err := io.ReadFull(br, buf); err != nil {
+			return nil, err
+		}
+		p := &srs.Vk.G2[i]
+		ptauElement(&p.X.A0, buf[:fp.Bytes])
+		ptauElement(&p.X.A1, buf[fp.Bytes:2*fp.Bytes])
+		ptauElement(&p.Y.A0, buf[2*fp.Bytes:3*fp.Bytes])
+		ptauElement(&p.Y.A1, buf[3*fp.Bytes:])
+		if !p.IsOnCurve() || !p.IsInSubGroup() {
+			return nil, fmt.Errorf("ptau point %d in G2 is not in the subgroup", i)
+		}
+	}
+
+	// the powers of tau start with the generators
+	_, _, g1, g2 := bn254.Generators()
+	if !srs.Pk.G1[0].Equal(&g1) || !srs.Vk.G2[0].Equal(&g2) {
+		return nil, errors.New("ptau file does not start with the generators")
+	}
+	srs.Vk.G1 = g1
+	srs.Vk.Lines[0] = bn254.PrecomputeLines(srs.Vk.G2[0])
+	srs.Vk.Lines[1] = bn254.PrecomputeLines(srs.Vk.G2[1])
+	return &srs, nil
+}
+
+// ptauSection returns a buffered reader at the start of the section
+func ptauSection(r io.ReadSeeker, offset int64) (*bufio.Reader, error) {
+	if _, err := r.Seek(offset, io.SeekStart); err != nil {
+		return nil, err
+	}
+	return bufio.NewReaderSize(r, 1<<20), nil
+}
+
+// ptauElement sets e from its little endian Montgomery form
+func ptauElement(e *fp.Element, b []byte) {
+	for i := range e {
+		e[i] = binary.LittleEndian.Uint64(b[8*i:])
+	}
+}
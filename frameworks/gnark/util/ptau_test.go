@@ -0,0 +1,124 @@
+package util
+
+import (
+	"bytes"
+	"encoding/binary"
+	"math/big"
+	"testing"
+
+	"github.com/consensys/gnark-crypto/ecc/bn254"
+	"github.com/consensys/gnark-crypto/ecc/bn254/fp"
+	"github.com/consensys/gnark-crypto/ecc/bn254/fr"
+	kzg_bn254 "github.com/consensys/gnark-crypto/ecc/bn254/kzg"
+	"github.com/consensys/gnark/test"
+)
+
+// ptau is the content of a snarkjs .ptau file over BN254
+type ptau struct {
+	modulus *big.Int
+	power   uint32
+	g1      []bn254.G1Affine
+	g2      []bn254.G2Affine
+}
+
+// newPtau returns the powers of tau of a ceremony of the given power, 2^(power+1)-1 in G1 and 2^power in G2
+func newPtau(tau int64, power uint32) ptau {
+	p := ptau{modulus: fp.Modulus(), power: power}
+	_, _, g1, g2 := bn254.Generators()
+	exp := big.NewInt(1)
+	for i := 0; i < 1<<(power+1)-1; i++ {
+		var q1 bn254.G1Affine
+		q1.ScalarMultiplication(&g1, exp)
+		p.g1 = append(p.g1, q1)
+		if i < 1<<power {
+			var q2 bn254.G2Affine
+			q2.ScalarMultiplication(&g2, exp)
+			p.g2 = append(p.g2, q2)
+		}
+		exp.Mul(exp, big.NewInt(tau))
+	}
+	return p
+}
+
+// bytes encodes the sections of the file, the coordinates little endian in Montgomery form
+func (p ptau) bytes() []byte {
+	element := func(b *bytes.Buffer, e fp.Element) {
+		for _, limb := range e {
+			binary.Write(b, binary.LittleEndian, limb)
+		}
+	}
+	var header, g1, g2 bytes.Buffer
+	modulus := p.modulus.FillBytes(make([]byte, fp.Bytes))
+	for i, j := 0, len(modulus)-1; i < j; i, j = i+1, j-1 {
+		modulus[i], modulus[j] = modulus[j], modulus[i]
+	}
+	binary.Write(&header, binary.LittleEndian, uint32(fp.Bytes))
+	header.Write(modulus)
+	binary.Write(&header, binary.LittleEndian, p.power)
+	binary.Write(&header, binary.LittleEndian, p.power)
+	for _, q := range p.g1 {
+		element(&g1, q.X)
+		element(&g1, q.Y)
+	}
+	for _, q := range p.g2 {
+		element(&g2, q.X.A0)
+		element(&g2, q.X.A1)
+		element(&g2, q.Y.A0)
+		element(&g2, q.Y.A1)
+	}
+
+	var file bytes.Buffer
+	file.WriteString("ptau")
+	binary.Write(&file, binary.LittleEndian, uint32(1))
+	binary.Write(&file, binary.LittleEndian, uint32(3))
+	for i, section := range []*bytes.Buffer{&header, &g1, &g2} {
+		binary.Write(&file, binary.LittleEndian, uint32(i+1))
+		binary.Write(&file, binary.LittleEndian, uint64(section.Len()))
+		file.Write(section.Bytes())
+	}
+	return file.Bytes()
+}
+
+func TestReadPtau(t *testing.T) {
+	assert := test.NewAssert(t)
+
+	const tau = 42
+	p := newPtau(tau, 3)
+
+	// the powers of tau of the file are those of the SRS generated from the same secret
+	srs, err := ReadPtau(bytes.NewReader(p.bytes()), 10)
+	assert.NoError(err)
+	expected, err := kzg_bn254.NewSRS(10, big.NewInt(tau))
+	assert.NoError(err)
+	assert.Equal(expected.Pk.G1, srs.Pk.G1)
+	assert.Equal(expected.Vk, srs.Vk)
+
+	// all the 2^4-1 points of a ceremony of power 3
+	srs, err = ReadPtau(bytes.NewReader(p.bytes()), 15)
+	assert.NoError(err)
+	assert.Equal(15, len(srs.Pk.G1))
+
+	_, err = ReadPtau(bytes.NewReader(p.bytes()), 16)
+	assert.ErrorContains(err, "too small")
+
+	wrongField := p
+	wrongField.modulus = fr.Modulus()
+	_, err = ReadPtau(bytes.NewReader(wrongField.bytes()), 10)
+	assert.ErrorContains(err, "not over BN254")
+
+	// the powers of another generator are on the curve but do not start with the generator
+	badGenerator := newPtau(tau, 3)
+	for i := range badGenerator.g1 {
+		badGenerator.g1[i].Double(&badGenerator.g1[i])
+	}
+	_, err = ReadPtau(bytes.NewReader(badGenerator.bytes()), 10)
+	assert.ErrorContains(err, "generators")
+
+	offCurve := newPtau(tau, 3)
+	offCurve.g1[1].X.SetOne()
+	_, err = ReadPtau(bytes.NewReader(offCurve.bytes()), 10)
+	assert.ErrorContains(err, "not on the curve")
+
+	_, err = ReadPtau(bytes.NewReader([]byte("zkey")), 10)
+	assert.ErrorContains(err, "not a ptau file")
+}
@@ -0,0 +1,8 @@
+//go:build !linux
+
+package util
+
+// readRSS is only supported on Linux, the timeline reports a resident set size of 0 elsewhere
+func readRSS() uint64 {
+	return 0
+}
@@ -0,0 +1,17 @@
+package util
+
+import (
+	"errors"
+	"os"
+	"syscall"
+)
+
+// maxRSS returns the peak resident set size of the exited process in kilobytes,
+// which is the unit of ru_maxrss on Linux
+func maxRSS(state *os.ProcessState) (uint64, error) {
+	rusage, ok := state.SysUsage().(*syscall.Rusage)
+	if !ok {
+		return 0, errors.New("resource usage of the child process is not available")
+	}
+	return uint64(rusage.Maxrss), nil
+}
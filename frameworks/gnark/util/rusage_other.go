@@ -0,0 +1,13 @@
+//go:build !linux && !darwin
+
+package util
+
+import (
+	"errors"
+	"os"
+)
+
+// maxRSS is only supported on Linux and macOS
+func maxRSS(state *os.ProcessState) (uint64, error) {
+	return 0, errors.New("peak resident set size is only supported on linux and darwin")
+}
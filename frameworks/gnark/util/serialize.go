@@ -0,0 +1,80 @@
+package util
+
+import (
+	"bytes"
+	"io"
+	"time"
+)
+
+// rawWriter is implemented by the objects holding curve points, which can be written uncompressed
+type rawWriter interface {
+	WriteRawTo(w io.Writer) (int64, error)
+}
+
+// Serialization is the wire size of an object in an encoding and the time of one of its operations,
+// WriteTo, WriteRawTo or ReadFrom
+type Serialization struct {
+	Object    string
+	Encoding  string
+	Operation string
+	Bytes     int
+	Took      time.Duration
+}
+
+type SerializationWriteFunction func(s Serialization)
+
+// SerializedSize returns the number of bytes written by the object's WriteTo, e.g. the wire size of a proof
+func SerializedSize(o io.WriterTo) int {
+	n, err := o.WriteTo(io.Discard)
+	if err != nil {
+		panic(err)
+	}
+	return int(n)
+}
+
+// Serialize writes the object count times with WriteTo and, if it holds curve points, with WriteRawTo,
+// and reads every encoding back count times into an object allocated by newObject.
+// The objects are written to memory, such that the times are those of the encoding only.
+// Objects without points have a single encoding, reported as none, the others compressed and raw.
+func Serialize(object string, o io.WriterTo, newObject func() io.ReaderFrom, count int) ([]Serialization, error) {
+	type encoding struct {
+		name      string
+		operation string
+		write     func(w io.Writer) (int64, error)
+	}
+	encodings := []encoding{{"none", "WriteTo", o.WriteTo}}
+	if raw, ok := o.(rawWriter); ok {
+		encodings = []encoding{
+			{"compressed", "WriteTo", o.WriteTo},
+			{"raw", "WriteRawTo", raw.WriteRawTo},
+		}
+	}
+
+	var res []Serialization
+	for _, e := range encodings {
+		var buf bytes.Buffer
+		var took time.Duration
+		for i := 0; i < count; i++ {
+			buf.Reset()
+			start := time.Now()
+			if _, err := e.write(&buf); err != nil {
+				return nil, err
+			}
+			took += time.Since(start)
+		}
+		data := buf.Bytes()
+		res = append(res, Serialization{object, e.name, e.operation, len(data), took / time.Duration(count)})
+
+		took = 0
+		for i := 0; i < count; i++ {
+			r := newObject()
+			start := time.Now()
+			if _, err := r.ReadFrom(bytes.NewReader(data)); err != nil {
+				return nil, err
+			}
+			took += time.Since(start)
+		}
+		res = append(res, Serialization{object, e.name, "ReadFrom", len(data), took / time.Duration(count)})
+	}
+	return res, nil
+}
@@ -0,0 +1,162 @@
+package util
+
+import (
+	"bufio"
+	"crypto/rand"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/consensys/gnark-crypto/ecc"
+	"github.com/consensys/gnark-crypto/kzg"
+	"github.com/consensys/gnark/constraint"
+
+	kzg_bls12377 "github.com/consensys/gnark-crypto/ecc/bls12-377/kzg"
+	kzg_bls12381 "github.com/consensys/gnark-crypto/ecc/bls12-381/kzg"
+	kzg_bls24315 "github.com/consensys/gnark-crypto/ecc/bls24-315/kzg"
+	kzg_bls24317 "github.com/consensys/gnark-crypto/ecc/bls24-317/kzg"
+	kzg_bn254 "github.com/consensys/gnark-crypto/ecc/bn254/kzg"
+	kzg_bw6633 "github.com/consensys/gnark-crypto/ecc/bw6-633/kzg"
+	kzg_bw6761 "github.com/consensys/gnark-crypto/ecc/bw6-761/kzg"
+)
+
+// SRSSize returns the number of points of the canonical and of the Lagrange SRS needed by the PlonK setup,
+// the canonical SRS having 3 more points for the opening of the blinded polynomials
+func SRSSize(ccs constraint.ConstraintSystem) (canonical int, lagrange int) {
+	lagrange = int(ecc.NextPowerOfTwo(uint64(ccs.GetNbConstraints() + ccs.GetNbPublicVariables())))
+	return lagrange + 3, lagrange
+}
+
+// ReadSRS reads a canonical SRS of at least size points over the curve from the file at path,
+// either a .ptau file of snarkjs over BN254 or a kzg.SRS serialized by gnark-crypto
+func ReadSRS(curveID ecc.ID, path string, size int) (kzg.SRS, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	if filepath.Ext(path) == ".ptau" {
+		if curveID != ecc.BN254 {
+			return nil, errors.New("ptau files are only supported over BN254")
+		}
+		return ReadPtau(f, size)
+	}
+
+	srs := kzg.NewSRS(curveID)
+	if _, err := srs.ReadFrom(bufio.NewReaderSize(f, 1<<20)); err != nil {
+		return nil, fmt.Errorf("read srs: %w", err)
+	}
+	if n := srsLen(srs); n < size {
+		return nil, fmt.Errorf("srs is too small: got %d points, need %d", n, size)
+	}
+	return srs, nil
+}
+
+// GenerateSRS generates a canonical SRS of size points over the curve from a random secret.
+// The secret is not destroyed, such that the SRS is only fit for benchmarks.
+func GenerateSRS(curveID ecc.ID, size int) (kzg.SRS, error) {
+	tau, err := rand.Int(rand.Reader, curveID.ScalarField())
+	if err != nil {
+		return nil, err
+	}
+	switch curveID {
+	case ecc.BN254:
+		return kzg_bn254.NewSRS(uint64(size), tau)
+	case ecc.BLS12_381:
+		return kzg_bls12381.NewSRS(uint64(size), tau)
+	case ecc.BLS12_377:
+		return kzg_bls12377.NewSRS(uint64(size), tau)
+	case ecc.BW6_761:
+		return kzg_bw6761.NewSRS(uint64(size), tau)
+	case ecc.BLS24_317:
+		return kzg_bls24317.NewSRS(uint64(size), tau)
+	case ecc.BLS24_315:
+		return kzg_bls24315.NewSRS(uint64(size), tau)
+	case ecc.BW6_633:
+		return kzg_bw6633.NewSRS(uint64(size), tau)
+	default:
+		return nil, errors.New("srs over unsupported curve")
+	}
+}
+
+// TrimSRS returns the canonical SRS reduced to its first size points
+func TrimSRS(canonical kzg.SRS, size int) (kzg.SRS, error) {
+	if n := srsLen(canonical); n < size {
+		return nil, fmt.Errorf("srs is too small: got %d points, need %d", n, size)
+	}
+	switch srs := canonical.(type) {
+	case *kzg_bn254.SRS:
+		return &kzg_bn254.SRS{Pk: kzg_bn254.ProvingKey{G1: srs.Pk.G1[:size]}, Vk: srs.Vk}, nil
+	case *kzg_bls12381.SRS:
+		return &kzg_bls12381.SRS{Pk: kzg_bls12381.ProvingKey{G1: srs.Pk.G1[:size]}, Vk: srs.Vk}, nil
+	case *kzg_bls12377.SRS:
+		return &kzg_bls12377.SRS{Pk: kzg_bls12377.ProvingKey{G1: srs.Pk.G1[:size]}, Vk: srs.Vk}, nil
+	case *kzg_bw6761.SRS:
+		return &kzg_bw6761.SRS{Pk: kzg_bw6761.ProvingKey{G1: srs.Pk.G1[:size]}, Vk: srs.Vk}, nil
+	case *kzg_bls24317.SRS:
+		return &kzg_bls24317.SRS{Pk: kzg_bls24317.ProvingKey{G1: srs.Pk.G1[:size]}, Vk: srs.Vk}, nil
+	case *kzg_bls24315.SRS:
+		return &kzg_bls24315.SRS{Pk: kzg_bls24315.ProvingKey{G1: srs.Pk.G1[:size]}, Vk: srs.Vk}, nil
+	case *kzg_bw6633.SRS:
+		return &kzg_bw6633.SRS{Pk: kzg_bw6633.ProvingKey{G1: srs.Pk.G1[:size]}, Vk: srs.Vk}, nil
+	default:
+		return nil, errors.New("srs over unsupported curve")
+	}
+}
+
+// ToLagrange converts the first size points of the canonical SRS to the Lagrange form
+// over the domain of that size, a power of two
+func ToLagrange(canonical kzg.SRS, size int) (kzg.SRS, error) {
+	if n := srsLen(canonical); n < size {
+		return nil, fmt.Errorf("srs is too small: got %d points, need %d", n, size)
+	}
+	switch srs := canonical.(type) {
+	case *kzg_bn254.SRS:
+		g1, err := kzg_bn254.ToLagrangeG1(srs.Pk.G1[:size])
+		return &kzg_bn254.SRS{Pk: kzg_bn254.ProvingKey{G1: g1}, Vk: srs.Vk}, err
+	case *kzg_bls12381.SRS:
+		g1, err := kzg_bls12381.ToLagrangeG1(srs.Pk.G1[:size])
+		return &kzg_bls12381.SRS{Pk: kzg_bls12381.ProvingKey{G1: g1}, Vk: srs.Vk}, err
+	case *kzg_bls12377.SRS:
+		g1, err := kzg_bls12377.ToLagrangeG1(srs.Pk.G1[:size])
+		return &kzg_bls12377.SRS{Pk: kzg_bls12377.ProvingKey{G1: g1}, Vk: srs.Vk}, err
+	case *kzg_bw6761.SRS:
+		g1, err := kzg_bw6761.ToLagrangeG1(srs.Pk.G1[:size])
+		return &kzg_bw6761.SRS{Pk: kzg_bw6761.ProvingKey{G1: g1}, Vk: srs.Vk}, err
+	case *kzg_bls24317.SRS:
+		g1, err := kzg_bls24317.ToLagrangeG1(srs.Pk.G1[:size])
+		return &kzg_bls24317.SRS{Pk: kzg_bls24317.ProvingKey{G1: g1}, Vk: srs.Vk}, err
+	case *kzg_bls24315.SRS:
+		g1, err := kzg_bls24315.ToLagrangeG1(srs.Pk.G1[:size])
+		return &kzg_bls24315.SRS{Pk: kzg_bls24315.ProvingKey{G1: g1}, Vk: srs.Vk}, err
+	case *kzg_bw6633.SRS:
+		g1, err := kzg_bw6633.ToLagrangeG1(srs.Pk.G1[:size])
+		return &kzg_bw6633.SRS{Pk: kzg_bw6633.ProvingKey{G1: g1}, Vk: srs.Vk}, err
+	default:
+		return nil, errors.New("srs over unsupported curve")
+	}
+}
+
+// srsLen returns the number of G1 points of the SRS
+func srsLen(srs kzg.SRS) int {
+	switch srs := srs.(type) {
+	case *kzg_bn254.SRS:
+		return len(srs.Pk.G1)
+	case *kzg_bls12381.SRS:
+		return len(srs.Pk.G1)
+	case *kzg_bls12377.SRS:
+		return len(srs.Pk.G1)
+	case *kzg_bw6761.SRS:
+		return len(srs.Pk.G1)
+	case *kzg_bls24317.SRS:
+		return len(srs.Pk.G1)
+	case *kzg_bls24315.SRS:
+		return len(srs.Pk.G1)
+	case *kzg_bw6633.SRS:
+		return len(srs.Pk.G1)
+	default:
+		return 0
+	}
+}
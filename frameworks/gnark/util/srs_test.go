@@ -0,0 +1,58 @@
+package util
+
+import (
+	"testing"
+
+	"github.com/consensys/gnark-crypto/ecc"
+	kzg_bls12381 "github.com/consensys/gnark-crypto/ecc/bls12-381/kzg"
+	kzg_bn254 "github.com/consensys/gnark-crypto/ecc/bn254/kzg"
+	"github.com/consensys/gnark/frontend"
+	"github.com/consensys/gnark/frontend/cs/scs"
+	"github.com/consensys/gnark/test"
+	"github.com/consensys/gnark/test/unsafekzg"
+	"github.com/zkCollective/zk-Harness/frameworks/gnark/circuits/toy/rangecheck"
+)
+
+func TestLagrangeSRS(t *testing.T) {
+	assert := test.NewAssert(t)
+
+	for _, curveID := range []ecc.ID{ecc.BN254, ecc.BLS12_381} {
+		circuit := &rangecheck.RangeCheckCircuit{X: make([]frontend.Variable, 4), Bits: 8}
+		ccs, err := frontend.Compile(curveID.ScalarField(), scs.NewBuilder, circuit)
+		assert.NoError(err)
+
+		// unsafekzg computes the Lagrange SRS from the secret directly, not from the canonical points
+		canonical, expected, err := unsafekzg.NewSRS(ccs)
+		assert.NoError(err)
+		canonicalSize, lagrangeSize := SRSSize(ccs)
+		assert.Equal(canonicalSize, srsLen(canonical), curveID.String())
+		assert.Equal(lagrangeSize, srsLen(expected), curveID.String())
+
+		lagrange, err := ToLagrange(canonical, lagrangeSize)
+		assert.NoError(err)
+		assert.Equal(expected, lagrange, curveID.String())
+
+		_, err = ToLagrange(canonical, canonicalSize+1)
+		assert.ErrorContains(err, "srs is too small")
+	}
+}
+
+func TestTrimSRS(t *testing.T) {
+	assert := test.NewAssert(t)
+
+	srs, err := GenerateSRS(ecc.BN254, 20)
+	assert.NoError(err)
+	trimmed, err := TrimSRS(srs, 11)
+	assert.NoError(err)
+	assert.Equal(srs.(*kzg_bn254.SRS).Pk.G1[:11], trimmed.(*kzg_bn254.SRS).Pk.G1)
+	assert.Equal(srs.(*kzg_bn254.SRS).Vk, trimmed.(*kzg_bn254.SRS).Vk)
+
+	_, err = TrimSRS(srs, 21)
+	assert.ErrorContains(err, "srs is too small")
+
+	srs, err = GenerateSRS(ecc.BLS12_381, 8)
+	assert.NoError(err)
+	trimmed, err = TrimSRS(srs, 8)
+	assert.NoError(err)
+	assert.Equal(8, len(trimmed.(*kzg_bls12381.SRS).Pk.G1))
+}
@@ -0,0 +1,106 @@
+package util
+
+import (
+	"errors"
+	"runtime"
+	"strconv"
+	"strings"
+)
+
+// ParseThreads parses a comma separated list of thread counts, where "max" stands for
+// the number of logical CPUs. The single thread baseline is always run first and every
+// count is run once, in the order of its first occurrence.
+func ParseThreads(s string) ([]int, error) {
+	threads := []int{1}
+	for _, field := range strings.Split(s, ",") {
+		field = strings.TrimSpace(field)
+		if field == "" {
+			continue
+		}
+		n := runtime.NumCPU()
+		if field != "max" {
+			var err error
+			if n, err = strconv.Atoi(field); err != nil || n <= 0 {
+				return nil, errors.New("invalid thread count " + field)
+			}
+		}
+		if !contains(threads, n) {
+			threads = append(threads, n)
+		}
+	}
+	return threads, nil
+}
+
+// ParseCPUSet parses a list of CPUs in the cpuset format, e.g. "0-3,8,10-11"
+func ParseCPUSet(s string) ([]int, error) {
+	var cpus []int
+	for _, field := range strings.Split(s, ",") {
+		field = strings.TrimSpace(field)
+		if field == "" {
+			continue
+		}
+		bounds := strings.SplitN(field, "-", 2)
+		first, err := strconv.Atoi(bounds[0])
+		if err != nil || first < 0 {
+			return nil, errors.New("invalid cpu " + field)
+		}
+		last := first
+		if len(bounds) == 2 {
+			if last, err = strconv.Atoi(bounds[1]); err != nil || last < first {
+				return nil, errors.New("invalid cpu range " + field)
+			}
+		}
+		for cpu := first; cpu <= last; cpu++ {
+			cpus = append(cpus, cpu)
+		}
+	}
+	return cpus, nil
+}
+
+// SetThreads limits the Go scheduler to n threads running simultaneously and, if cpus is
+// not empty, pins the process to the first n CPUs of the set.
+//
+// Note that gnark sizes its parallel tasks with runtime.NumCPU(), which is not affected.
+func SetThreads(n int, cpus []int) error {
+	runtime.GOMAXPROCS(n)
+	if len(cpus) == 0 {
+		return nil
+	}
+	if n > len(cpus) {
+		return errors.New("cpu set has less CPUs than threads")
+	}
+	return pinProcess(cpus[:n])
+}
+
+// ThreadState is the scheduler limit and the CPU affinity of the process before SetThreads
+type ThreadState struct {
+	maxProcs int
+	cpus     []int
+}
+
+// SaveThreads saves the scheduler limit and the CPU affinity of the process
+func SaveThreads() (ThreadState, error) {
+	cpus, err := processAffinity()
+	if err != nil {
+		return ThreadState{}, err
+	}
+	return ThreadState{maxProcs: runtime.GOMAXPROCS(0), cpus: cpus}, nil
+}
+
+// Restore restores the scheduler limit and the CPU affinity saved by SaveThreads
+func (s ThreadState) Restore() error {
+	runtime.GOMAXPROCS(s.maxProcs)
+	if len(s.cpus) == 0 {
+		return nil
+	}
+	return pinProcess(s.cpus)
+}
+
+func contains(values []int, v int) bool {
+	for _, value := range values {
+		if value == v {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,88 @@
+package util
+
+import (
+	"runtime"
+	"testing"
+
+	"github.com/consensys/gnark/test"
+)
+
+func TestParseThreads(t *testing.T) {
+	assert := test.NewAssert(t)
+
+	// withMax appends the number of CPUs to the thread counts, unless it is already run
+	withMax := func(threads ...int) []int {
+		if !contains(threads, runtime.NumCPU()) {
+			threads = append(threads, runtime.NumCPU())
+		}
+		return threads
+	}
+
+	for _, tc := range []struct {
+		flag    string
+		threads []int
+	}{
+		{"", []int{1}},
+		{"1", []int{1}},
+		{"2,4", []int{1, 2, 4}},
+		{" 4 , 2 ,", []int{1, 4, 2}},
+		{"4,4,1", []int{1, 4}},
+		{"max", withMax(1)},
+		{"max,1,max", withMax(1)},
+		// an explicit count equal to the number of CPUs is not run twice
+		{"2,max,2", withMax(1, 2)},
+	} {
+		threads, err := ParseThreads(tc.flag)
+		assert.NoError(err, tc.flag)
+		assert.Equal(tc.threads, threads, tc.flag)
+	}
+
+	for _, flag := range []string{"0", "-2", "two", "2,maxi"} {
+		_, err := ParseThreads(flag)
+		assert.Error(err, flag)
+	}
+}
+
+func TestParseCPUSet(t *testing.T) {
+	assert := test.NewAssert(t)
+
+	for _, tc := range []struct {
+		flag string
+		cpus []int
+	}{
+		{"", nil},
+		{"3", []int{3}},
+		{"0-3,8,10-11", []int{0, 1, 2, 3, 8, 10, 11}},
+		{" 2-2 ,", []int{2}},
+	} {
+		cpus, err := ParseCPUSet(tc.flag)
+		assert.NoError(err, tc.flag)
+		assert.Equal(tc.cpus, cpus, tc.flag)
+	}
+
+	for _, flag := range []string{"-1", "a", "3-1", "0-b", "1,,x"} {
+		_, err := ParseCPUSet(flag)
+		assert.Error(err, flag)
+	}
+}
+
+func TestRestoreThreads(t *testing.T) {
+	assert := test.NewAssert(t)
+
+	saved, err := SaveThreads()
+	assert.NoError(err)
+	maxProcs := runtime.GOMAXPROCS(0)
+
+	cpus := saved.cpus
+	if runtime.GOOS != "linux" {
+		cpus = nil
+	}
+	assert.NoError(SetThreads(1, cpus))
+	assert.Equal(1, runtime.GOMAXPROCS(0))
+
+	assert.NoError(saved.Restore())
+	assert.Equal(maxProcs, runtime.GOMAXPROCS(0))
+	restored, err := SaveThreads()
+	assert.NoError(err)
+	assert.Equal(saved.cpus, restored.cpus, "affinity restored")
+}
@@ -0,0 +1,147 @@
+package util
+
+import (
+	"encoding/csv"
+	"os"
+	"runtime/metrics"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/consensys/gnark/logger"
+	"github.com/rs/zerolog"
+)
+
+// MemSample is the memory usage of the process at an instant of the timed region
+type MemSample struct {
+	Elapsed   time.Duration
+	RSS       uint64
+	HeapInUse uint64
+	Phase     string
+}
+
+// TimelineWriteFunction writes the memory timeline of the benchmarked operation
+type TimelineWriteFunction func(string, []MemSample)
+
+// heapInUseMetrics sum up to the heap in use of runtime.MemStats, read without stopping the world
+var heapInUseMetrics = []string{"/memory/classes/heap/objects:bytes", "/memory/classes/heap/unused:bytes"}
+
+// Timeline samples the resident set size and the Go heap in use of the process at a fixed interval
+type Timeline struct {
+	start    time.Time
+	mu       sync.Mutex
+	phase    string
+	samples  []MemSample
+	previous zerolog.Logger
+	marked   bool
+	stop     chan struct{}
+	done     chan struct{}
+}
+
+// StartTimeline starts sampling the memory every interval. If markProver is set, the samples are marked
+// with the prover phase, solve or prove, as logged by the constraint system solver and the prover.
+func StartTimeline(interval time.Duration, markProver bool) *Timeline {
+	t := &Timeline{
+		start: time.Now(),
+		stop:  make(chan struct{}),
+		done:  make(chan struct{}),
+	}
+	if markProver {
+		t.phase = "solve"
+		t.previous = logger.Logger()
+		t.marked = true
+		logger.Set(t.previous.Hook(zerolog.HookFunc(func(e *zerolog.Event, level zerolog.Level, msg string) {
+			switch msg {
+			case "constraint system solver done":
+				t.mark("prove")
+			case "prover done":
+				// the next prover run starts solving
+				t.mark("solve")
+			}
+		})))
+	}
+	t.sample()
+
+	go func() {
+		defer close(t.done)
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ticker.C:
+				t.sample()
+			case <-t.stop:
+				return
+			}
+		}
+	}()
+	return t
+}
+
+// Stop stops sampling and returns the samples, the last one being taken at the end of the timed region
+func (t *Timeline) Stop() []MemSample {
+	close(t.stop)
+	<-t.done
+	t.sample()
+	if t.marked {
+		logger.Set(t.previous)
+	}
+	return t.samples
+}
+
+// mark takes a sample at the boundary of a phase, the following samples belonging to the phase
+func (t *Timeline) mark(phase string) {
+	t.mu.Lock()
+	t.phase = phase
+	t.mu.Unlock()
+	t.sample()
+}
+
+func (t *Timeline) sample() {
+	m := make([]metrics.Sample, len(heapInUseMetrics))
+	for i, name := range heapInUseMetrics {
+		m[i].Name = name
+	}
+	metrics.Read(m)
+	var heapInUse uint64
+	for i := range m {
+		if m[i].Value.Kind() == metrics.KindUint64 {
+			heapInUse += m[i].Value.Uint64()
+		}
+	}
+
+	s := MemSample{
+		Elapsed:   time.Since(t.start),
+		RSS:       readRSS(),
+		HeapInUse: heapInUse,
+	}
+	t.mu.Lock()
+	s.Phase = t.phase
+	t.samples = append(t.samples, s)
+	t.mu.Unlock()
+}
+
+// WriteTimeline writes the samples to a CSV file, the time being in microseconds since the start
+// of the timed region and the memory in bytes
+func WriteTimeline(filename string, samples []MemSample) error {
+	file, err := os.Create(filename)
+	if err != nil {
+		return err
+	}
+	writer := csv.NewWriter(file)
+	writer.Write([]string{"time", "rss", "heapInUse", "phase"})
+	for _, s := range samples {
+		writer.Write([]string{
+			strconv.FormatInt(s.Elapsed.Microseconds(), 10),
+			strconv.FormatUint(s.RSS, 10),
+			strconv.FormatUint(s.HeapInUse, 10),
+			s.Phase,
+		})
+	}
+	writer.Flush()
+	if err := writer.Error(); err != nil {
+		file.Close()
+		return err
+	}
+	return file.Close()
+}
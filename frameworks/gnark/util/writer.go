@@ -13,9 +13,47 @@ import (
 // convert types take an int and return a string value.
 type WriteFunction func(time.Duration, constraint.ConstraintSystem, int)
 
+// PhaseWriteFunction writes the time taken by a named sub-phase of the benchmarked algorithm.
+type PhaseWriteFunction func(string, time.Duration, constraint.ConstraintSystem)
+
+// GasWriteFunction writes the gas of the Solidity verifier of a circuit and the size of its bytecode.
+type GasWriteFunction func(GasCost, int, constraint.ConstraintSystem)
+
+// ResultSink stores the results written by WriteData along with the times of the iterations they average.
+type ResultSink interface {
+	Store(data interface{}, samples []time.Duration) error
+}
+
+// Sink additionally stores every result written by WriteData, e.g. in the database of --db, if set.
+var Sink ResultSink
+
+// samples are the times of the iterations recorded since the last result.
+var samples []time.Duration
+
+// AddSample records the time of an iteration of the timed region, stored by the Sink with the next result.
+func AddSample(took time.Duration) {
+	samples = append(samples, took)
+}
+
 // WriteData writes the data to a file in either CSV or JSON format, based on the file format specified.
+// The data is then stored by the Sink, if set, whose failure is reported as a warning only, the data
+// being written already.
 func WriteData(fileFormat string, data interface{}, filename ...string) error {
+	iterations := samples
+	samples = nil
+	if err := writeFile(fileFormat, data, filename...); err != nil {
+		return err
+	}
+	if Sink != nil {
+		if err := Sink.Store(data, iterations); err != nil {
+			fmt.Fprintln(os.Stderr, "warning: the result is written but not stored: ", err.Error())
+		}
+	}
+	return nil
+}
 
+// writeFile writes the data to the file, or to stdout if none, in either CSV or JSON format
+func writeFile(fileFormat string, data interface{}, filename ...string) error {
 	var writer *csv.Writer
 	var jsonEncoder *json.Encoder
 	var file *os.File
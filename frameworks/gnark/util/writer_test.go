@@ -0,0 +1,51 @@
+package util
+
+import (
+	"encoding/csv"
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/consensys/gnark/test"
+)
+
+// failingSink fails to store every result, e.g. as its database is locked
+type failingSink struct {
+	calls int
+}
+
+func (s *failingSink) Store(data interface{}, samples []time.Duration) error {
+	s.calls++
+	return errors.New("database is locked")
+}
+
+func TestWriteDataSinkFailure(t *testing.T) {
+	assert := test.NewAssert(t)
+
+	result := func(circuit string, took int64) BenchDataCircuit {
+		return BenchDataCircuit{Framework: "gnark", Category: "circuit", Backend: "groth16", Curve: "BN254", Circuit: circuit, Operation: "prove", Count: 2, RunTime: took}
+	}
+	sink := &failingSink{}
+	Sink = sink
+	defer func() { Sink = nil }()
+
+	// the rows are written to the CSV although the sink fails to store them
+	filename := filepath.Join(t.TempDir(), "results.csv")
+	AddSample(time.Millisecond)
+	assert.NoError(WriteData("csv", result("sha2", 1000), filename))
+	assert.NoError(WriteData("csv", result("mimc", 10), filename))
+	assert.Equal(2, sink.calls)
+
+	f, err := os.Open(filename)
+	assert.NoError(err)
+	defer f.Close()
+	records, err := csv.NewReader(f).ReadAll()
+	assert.NoError(err)
+	assert.Len(records, 3)
+	assert.Equal(BenchDataCircuit{}.Headers(), records[0])
+	assert.Equal(result("sha2", 1000).Values(), records[1])
+	assert.Equal(result("mimc", 10).Values(), records[2])
+	assert.Empty(samples)
+}
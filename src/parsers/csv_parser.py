@@ -2,20 +2,11 @@ import argparse
 import pandas as pd
 import os
 
-def parse_memory_txt(circuit_folder, circuit):
-    memory_data = []
-    for filename in os.listdir(circuit_folder):
-        if filename.endswith(".txt"):
-            with open(os.path.join(circuit_folder, filename), 'r') as file:
-                lines = file.readlines()
-                for line in lines:
-                    if "maximum resident set size" in line:
-                        ram = int(line.split()[0])
-                        operation = filename.split("memory_")[-1].split("_")[0].replace(".txt", "")
-                        input_file = "input/circuit/" + circuit + "/input_" + circuit_folder.split("_")[-1] + ".json" 
-                        memory_data.append([input_file, operation, ram])
-    df_memory = pd.DataFrame(memory_data, columns=["input", "operation", "ramReal"])
-    return df_memory
+def read_memory_csv(filename):
+    # Rows of the gnark memory command, the peak RSS of each phase in kilobytes
+    df_memory = pd.read_csv(filename)
+    df_memory = df_memory.rename(columns={"maxRSS": "ramReal"})
+    return df_memory[["input", "operation", "ramReal"]]
 
 def read_time_csv(filename):
     df_time = pd.read_csv(filename)
@@ -29,16 +20,18 @@ def merge_memory_time(df_memory, df_time):
 def save_df(dataframe, filename):
     dataframe.to_csv(filename, index=False)
 
-def combine_memory_time(circuit_folder, filename, circuit):
-    df_memory = parse_memory_txt(circuit_folder, circuit)
+def combine_memory_time(memory_filename, filename):
+    if not os.path.exists(memory_filename):
+        print(f"No memory benchmarks in {memory_filename}")
+        return
+    df_memory = read_memory_csv(memory_filename)
     df_time = read_time_csv(filename)
     df_merged = merge_memory_time(df_memory, df_time)
     save_df(df_merged, filename)
 
 if __name__ == "__main__":
     parser = argparse.ArgumentParser()
-    parser.add_argument("--circuit", required=True, help="Circuit")
-    parser.add_argument("--memory_folder", required=True, help="Memory Folder")
+    parser.add_argument("--memory_filename", required=True, help="Memory Filename")
     parser.add_argument("--time_filename", required=True, help="Time Filename")
     args = parser.parse_args()
-    combine_memory_time(args.memory_folder, args.time_filename, args.circuit)
\ No newline at end of file
+    combine_memory_time(args.memory_filename, args.time_filename)
@@ -12,6 +12,12 @@ def recursion_processing(project, config, count):
     commands = process_recursion.build_command(project, payload, count)
     subprocess.run(commands, shell=True, check=True)
 
+def aggregation_processing(project, config, count):
+    # Extract relevant fields from config, build & execute command
+    payload = process_recursion.get_aggregation_payload(config)
+    commands = process_recursion.build_aggregation_command(project, payload, count)
+    subprocess.run(commands, shell=True, check=True)
+
 def circuit_processing(project, config, count):
     # Extract relevant fields from config, build & execute command
     payload = process_circuit.get_circuit_payload(config)
@@ -31,6 +37,7 @@ def default_case():
 # TODO - Add other modes (arithmetic & curves)
 categories = {
     "recursion": recursion_processing,
+    "aggregation": aggregation_processing,
     "circuit": circuit_processing,
 }
 
@@ -33,53 +33,39 @@ def build_command_gnark(payload, count):
                     for op in payload.operation
                     for _ in range(0,count)]
 
-        # Builder command memory
-        command_binary = f"{initial_cmd} ./build_memory.sh;"
-        # Create /tmp folder if non-existent
-        command_check_tmp = f"{initial_cmd} mkdir -p ./tmp;"
-
-        # Memory commands
+        # Memory commands, each phase running in a fresh child process of the memory command
         commands_memory = [
-            (
-                os.makedirs(f"{helper.Paths().GNARK_BENCH_MEMORY}/{modified_inp}", exist_ok=True),
-                f"{initial_cmd} {helper.get_memory_command()} ./{backend}_memory_{op} \
-                    --circuit={circ} \
-                    --curve={curve} \
-                    --input={inp} \
-                    --count=1 \
-                    2> {helper.Paths().GNARK_BENCH_MEMORY}/{modified_inp}/gnark_{backend}_{circ}_memory_{op}.txt \
-                    > /dev/null || true; \n"
-            )[1]
+            f"{initial_cmd} ./gnark memory \
+                --backend={backend} \
+                --phase={op} \
+                --circuit={circ} \
+                --curve={curve} \
+                --input={inp} \
+                --artifacts-dir=./tmp \
+                --outputPath={helper.Paths().GNARK_BENCH_MEMORY}/{backend}_{circ}.csv \
+                > /dev/null || true; \n"
             for backend in payload.backend
             for curve in payload.curves
             for circ, input_path in payload.circuit.items()
             for inp in helper.get_all_input_files(input_path)
-            for modified_inp in [inp.replace('input/circuit/', '').replace('.json', '')]
             for op in payload.operation
         ]
 
         commands_memory.append("cd ../../;")
 
         commands_merge = [
-            "python3 src/parsers/csv_parser.py --memory_folder {memory_folder}/{input_name} --time_filename {gnark_bench_folder}/{backend}_{circuit}.csv --circuit {circuit}; \n".format(
-                memory_folder=helper.Paths().GNARK_BENCH_MEMORY,
-                input_name=inp.replace('input/circuit/', '').replace('.json', ''),
-                gnark_bench_folder=helper.Paths().GNARK_BENCH,
-                backend=backend,
-                circuit=circ
+            "python3 src/parsers/csv_parser.py --memory_filename {memory_filename} --time_filename {time_filename}; \n".format(
+                memory_filename=f"{helper.Paths().GNARK_BENCH_MEMORY}/{backend}_{circ}.csv",
+                time_filename=f"{helper.Paths().GNARK_BENCH}/{backend}_{circ}.csv"
             )
             for backend in payload.backend
-            for circ, input_path in payload.circuit.items()
-            for op in payload.operation
-            for inp in helper.get_all_input_files(input_path)
+            for circ in payload.circuit.keys()
         ]
 
         # Join the commands into a single string
         pre_command = "".join(commands + commands_memory + commands_merge)
         
         command = f"cd {helper.Paths().GNARK_DIR}; \
-                    {command_binary} \
-                    {command_check_tmp} \
                     {pre_command}\n"
     else:
         raise ValueError("Missing payload fields for circuit mode")
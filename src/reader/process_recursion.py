@@ -14,13 +14,16 @@ def build_command_gnark_recursion(payload, count):
     """
     Build the command to invoke the gnark ZKP-framework given the payload
     """
-    # FIXME - hardcoded outer curve. Only implemented bls12_377/bw6_761 so far
-    if payload.outerBackend is not None and payload.outerCurve is not None:
-        commands = [f"./gnark recursion --circuit={circ} --algo={op} --curve=bw6_761 --input={inp} --count={count} --outerBackend={outerBackend}\n"
+    if payload.innerBackend is not None and payload.outerBackend is not None and payload.outerCurve is not None:
+        commands = [f"./gnark recursion --circuit={circ} --algo={op} --curve={curve} --innerCurve={innerCurve} --input={inp} --count={count} --innerBackend={innerBackend} --outerBackend={outerBackend} --depth={depth}\n"
                     for circ, input_path in payload.circuit.items()
                     for inp in helper.get_all_input_files(input_path)
                     for op in payload.operation
-                    for outerBackend in payload.outerBackend]
+                    for curve in payload.outerCurve
+                    for innerCurve in payload.innerCurve
+                    for innerBackend in payload.innerBackend
+                    for outerBackend in payload.outerBackend
+                    for depth in payload.depth]
 
         # Join the commands into a single string
         command = "".join(commands)
@@ -31,6 +34,28 @@ def build_command_gnark_recursion(payload, count):
         raise ValueError("Missing payload fields for circuit mode")
     return command
 
+def build_command_gnark_aggregation(payload, count):
+    """
+    Build the command to invoke the gnark aggregation benchmarks given the payload
+    """
+    commands = [f"./gnark aggregate --circuit={circ} --algo={op} --curve={curve} --innerCurve={innerCurve} --input={inp} --count={count} --innerBackend={innerBackend} --outerBackend={outerBackend} --proofs={nbProofs} --aggregation={aggregation}\n"
+                for circ, input_path in payload.circuit.items()
+                for inp in helper.get_all_input_files(input_path)
+                for op in payload.operation
+                for curve in payload.outerCurve
+                for innerCurve in payload.innerCurve
+                for innerBackend in payload.innerBackend
+                for outerBackend in payload.outerBackend
+                for nbProofs in payload.nbProofs
+                for aggregation in payload.aggregation
+                # batched aggregation folds the KZG openings of PlonK proofs only
+                if aggregation != "batched" or innerBackend == "plonk"]
+
+    command = "".join(commands)
+    print(command)
+    command = f"cd {helper.Paths().GNARK_DIR}; {command}"
+    return command
+
 def default_case():
     raise ValueError("Framework not integrated into the benchmarking framework!")
 
@@ -47,12 +72,23 @@ def build_command(project, payload, count):
     commands = projects.get(project, default_case)(payload, count)
     return commands
 
+# List ZKP-frameworks with aggregation benchmarks
+aggregation_projects = {
+    "gnark":    build_command_gnark_aggregation
+}
+
+def build_aggregation_command(project, payload, count):
+    """
+    Build the aggregation command to execute the given project with the given payload.
+    """
+    commands = aggregation_projects.get(project, default_case)(payload, count)
+    return commands
+
 
 def get_recursion_payload(config):
     """
     Extract the payload for category "circuit" given a config.json
     """
-    # FIXME - InnerCurve is currently not parsed
 
     # Extract the relevant fields from the configuration data
     payload = config.get('payload')
@@ -65,6 +101,11 @@ def get_recursion_payload(config):
     if len(innerBackend) == 0:
         raise ValueError("backend field is empty")
 
+    # The inner curve is set by the 2-chains, and chosen for the emulated recursion over bn254
+    innerCurve = payload.get('innerCurve')
+    if innerCurve is None or len(innerCurve) == 0:
+        innerCurve = ["none"]
+
     curve = payload.get('outerCurve')
     if curve is None:
         raise KeyError("curves field does not exist in circuit payload")
@@ -103,9 +144,39 @@ def get_recursion_payload(config):
 
     # Map circuit names onto input paths
     circuit = dict(zip(circuits, input_path))
+
+    # The number of recursion levels, a single level by default
+    depth = payload.get('depth')
+    if depth is None or len(depth) == 0:
+        depth = [1]
+    for d in depth:
+        if d < 1:
+            raise ValueError(f"depth '{d}' must be >= 1")
     
     # Define a named tuple for the payload
-    Payload = namedtuple('Payload', ['innerBackend', 'outerBackend', 'outerCurve', 'circuit', 'operation', 'input_path'])
+    Payload = namedtuple('Payload', ['innerBackend', 'outerBackend', 'innerCurve', 'outerCurve', 'circuit', 'operation', 'input_path', 'depth'])
 
     # Return a new instance of the named tuple with the extracted values
-    return Payload(innerBackend, outerBackend, curve, circuit, operation, input_path)
+    return Payload(innerBackend, outerBackend, innerCurve, curve, circuit, operation, input_path, depth)
+
+def get_aggregation_payload(config):
+    """
+    Extract the payload for category "aggregation" given a config.json,
+    the recursion payload plus the numbers of proofs and the aggregation modes
+    """
+    recursion = get_recursion_payload(config)
+    payload = config['payload']
+
+    nbProofs = payload.get('nbProofs')
+    if nbProofs is None:
+        raise KeyError("nbProofs field does not exist in aggregation payload")
+    if len(nbProofs) == 0:
+        raise ValueError("nbProofs field is empty")
+
+    aggregation = payload.get('aggregation', ["independent"])
+    for a in aggregation:
+        if a not in ["independent", "batched"]:
+            raise ValueError(f"aggregation '{a}' not in ['independent', 'batched']")
+
+    Payload = namedtuple('Payload', recursion._fields + ('nbProofs', 'aggregation'))
+    return Payload(*recursion, nbProofs, aggregation)